@@ -0,0 +1,9 @@
+# benchgate fails when a benchmark allocates more per op than in the baseline, testdata/benchmarks.txt.
+# The allocations are deterministic, unlike the timings, so that the baseline holds on any machine.
+# Usage: awk -f .github/benchgate.awk testdata/benchmarks.txt new.txt
+{ name = $1; sub(/-[0-9]+$/, "", name) }
+NR == FNR { if (name ~ /^Benchmark/) base[name] = $(NF-1); next }
+name ~ /^Benchmark/ && (name in base) && $(NF-1) + 0 > base[name] + 0 {
+	print name ": " $(NF-1) " allocs/op, baseline " base[name]; fail = 1
+}
+END { exit fail }
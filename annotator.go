@@ -0,0 +1,23 @@
+package errors
+
+// SpanAnnotator annotates errors on the span of a tracing library.
+// The OpenCensus spans of the T-suffixed constructors and of WithSpan are annotated by an OpenCensus SpanAnnotator.
+// The spans of other libraries are annotated by their own SpanAnnotator, set with WithSpanAnnotator,
+// e.g. the OpenTelemetry one of the errotel package.
+type SpanAnnotator interface {
+	// TraceContext returns the trace context of the span.
+	// AnnotateError is only called when its sampled flag is set, see TraceContext.Sampled.
+	TraceContext() TraceContext
+	// AnnotateError records the error with the message msg, created at src, on the span,
+	// and sets the status of the span to code.
+	AnnotateError(msg string, src SourceLocation, code Code)
+}
+
+// FieldsAnnotator is a SpanAnnotator that also records the fields of the errors as span attributes.
+// The fields are redacted, see RedactedFields, and their keys are prefixed by Options.FieldAttributePrefix.
+type FieldsAnnotator interface {
+	SpanAnnotator
+	// AnnotateErrorFields is AnnotateError with the fields of the error, sorted by key.
+	// It is called instead of AnnotateError when the error has fields.
+	AnnotateErrorFields(msg string, src SourceLocation, code Code, fields []Field)
+}
@@ -0,0 +1,81 @@
+package errors
+
+import (
+	"runtime"
+	"strings"
+)
+
+// IsAppFrame reports whether src is a frame of the application, rather than of a dependency or the standard library,
+// see Options.AppPackages. It is meant for the adapters of error trackers that flag the frames of the application.
+func IsAppFrame(src SourceLocation) bool {
+	return isAppFunction(src.Function)
+}
+
+// isAppFunction reports whether function is a function of the application, see IsAppFrame.
+func isAppFunction(function string) bool {
+	// The external test packages are in the directory of the package they test.
+	pkg := strings.TrimSuffix(funcPackage(function), "_test")
+	if pkg == "main" {
+		return true
+	}
+	if prefixes := config().AppPackages; len(prefixes) > 0 {
+		for _, prefix := range prefixes {
+			if pkg == prefix || strings.HasPrefix(pkg, prefix+"/") {
+				return true
+			}
+		}
+		return false
+	}
+	if mainModule != "" {
+		return pkg == mainModule || strings.HasPrefix(pkg, mainModule+"/")
+	}
+	// The import paths of the standard library have no dot in their first element.
+	first, _, _ := strings.Cut(pkg, "/")
+	return strings.Contains(first, ".")
+}
+
+// AppFramesOf returns the frames of the application, see Options.AppPackages, of the stack captured with
+// the outermost error of this package in the chain of err, or else of the frames of its chain.
+func AppFramesOf(err error) []SourceLocation {
+	if e := contextOf(err); e != nil {
+		return e.AppFrames()
+	}
+	return nil
+}
+
+// AppFrames returns the frames of the application of the error, see AppFramesOf.
+func (e *errorContext) AppFrames() []SourceLocation {
+	frames := e.Stack()
+	if len(frames) == 0 {
+		frames = e.Frames()
+	}
+	var app []SourceLocation
+	for _, src := range frames {
+		if IsAppFrame(src) {
+			app = append(app, src)
+		}
+	}
+	return app
+}
+
+// setStack sets the stack of e to pcs.
+// With Options.AppSourceLocation, the source location of e is set to the first frame of the application in pcs,
+// if any, rather than to the frame of a helper or a middleware of a dependency.
+func (e *errorContext) setStack(pcs []uintptr) {
+	e.stack = pcs
+	if len(pcs) == 0 || !config().AppSourceLocation {
+		return
+	}
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if isAppFunction(frame.Function) {
+			src := SourceLocation{frame.Function, trimPath(frame.Function, frame.File), frame.Line, VERSION, COMMIT, BRANCH}
+			e.sourceLocation = location{src: &src}
+			return
+		}
+		if !more {
+			return
+		}
+	}
+}
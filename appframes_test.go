@@ -0,0 +1,40 @@
+//go:build !tracedisabled
+
+package errors_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleOptions_appSourceLocation() {
+	errors.Configure(errors.Options{AppSourceLocation: true})
+	defer errors.Configure(errors.Options{})
+
+	err := errors.Go(func() error {
+		fmt.Println(strings.Repeat("a", -1))
+		return nil
+	})
+	fmt.Println(err.(errors.ErrorTracer).SourceLocation().Function)
+	fmt.Println(errors.StackOf(err)[0].Function)
+
+	// Output:
+	// github.com/bzon/errors_test.ExampleOptions_appSourceLocation.func1
+	// strings.Repeat
+}
+
+func ExampleOptions_appPackages() {
+	errors.Configure(errors.Options{AppPackages: []string{"github.com/bzon/errors"}})
+	defer errors.Configure(errors.Options{})
+
+	err := errors.WithStack(fmt.Errorf("a"))
+	for _, src := range errors.AppFramesOf(err) {
+		fmt.Println(src.Function)
+	}
+
+	// Output:
+	// github.com/bzon/errors_test.ExampleOptions_appPackages
+	// main.main
+}
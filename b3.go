@@ -0,0 +1,72 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Zipkin B3 propagation headers.
+// See https://github.com/openzipkin/b3-propagation.
+const (
+	b3Header        = "b3"
+	b3TraceIDHeader = "X-B3-TraceId"
+	b3SpanIDHeader  = "X-B3-SpanId"
+	b3SampledHeader = "X-B3-Sampled"
+	b3FlagsHeader   = "X-B3-Flags"
+)
+
+// TraceContextFromB3 returns the trace context of the Zipkin B3 headers of h.
+// The single b3 header is preferred over the multiple X-B3-* headers.
+// 64-bit trace IDs are left-padded with zeros to 128 bits.
+// Use WithTraceContext to set it on an error.
+func TraceContextFromB3(h http.Header) (TraceContext, error) {
+	if v := h.Get(b3Header); v != "" {
+		parts := strings.Split(v, "-")
+		if len(parts) < 2 {
+			return TraceContext{}, fmt.Errorf("invalid b3 %q", v)
+		}
+		sampled := ""
+		if len(parts) > 2 {
+			sampled = parts[2]
+		}
+		return newB3TraceContext(parts[0], parts[1], sampled)
+	}
+	sampled := h.Get(b3SampledHeader)
+	if h.Get(b3FlagsHeader) == "1" {
+		sampled = "d"
+	}
+	return newB3TraceContext(h.Get(b3TraceIDHeader), h.Get(b3SpanIDHeader), sampled)
+}
+
+func newB3TraceContext(traceID, spanID, sampled string) (TraceContext, error) {
+	if len(traceID) == 16 {
+		traceID = strings.Repeat("0", 16) + traceID
+	}
+	if len(traceID) != 32 || !isHex(traceID) || traceID == strings.Repeat("0", 32) {
+		return TraceContext{}, fmt.Errorf("invalid b3 trace ID %q", traceID)
+	}
+	if len(spanID) != 16 || !isHex(spanID) {
+		return TraceContext{}, fmt.Errorf("invalid b3 span ID %q", spanID)
+	}
+	tc := TraceContext{TraceID: traceID, SpanID: spanID}
+	switch sampled {
+	case "1", "true", "d":
+		tc.TraceFlags = 1
+	}
+	return tc, nil
+}
+
+// B3Headers returns the trace context as the multiple X-B3-* Zipkin B3 headers,
+// to inject it into downstream requests.
+// It returns nil when there is no trace context.
+func (t TraceContext) B3Headers() http.Header {
+	if t.TraceID == "" || t.SpanID == "" {
+		return nil
+	}
+	h := http.Header{}
+	h.Set(b3TraceIDHeader, t.TraceID)
+	h.Set(b3SpanIDHeader, t.SpanID)
+	h.Set(b3SampledHeader, fmt.Sprint(t.TraceFlags&1))
+	return h
+}
@@ -0,0 +1,52 @@
+package errors_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleTraceContextFromB3() {
+	h := http.Header{}
+	h.Set("X-B3-TraceId", "463ac35c9f6413ad")
+	h.Set("X-B3-SpanId", "a2fb4a1d1a96d312")
+	h.Set("X-B3-Sampled", "1")
+	tc, err := errors.TraceContextFromB3(h)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(tc)
+
+	tc, err = errors.TraceContextFromB3(http.Header{"B3": {"80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-0"}})
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(tc)
+
+	_, err = errors.TraceContextFromB3(http.Header{})
+	fmt.Println(err)
+
+	// Output:
+	// {0000000000000000463ac35c9f6413ad a2fb4a1d1a96d312 1}
+	// {80f198ee56343ba864fe8b2a57d3eff7 e457b5a2e4d86bd1 0}
+	// invalid b3 trace ID ""
+}
+
+func ExampleTraceContext_B3Headers() {
+	tc := errors.TraceContext{
+		TraceID:    "80f198ee56343ba864fe8b2a57d3eff7",
+		SpanID:     "e457b5a2e4d86bd1",
+		TraceFlags: 1,
+	}
+	err := errors.NewO("a", errors.WithTraceContext(tc))
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	for k, v := range err.(errors.ErrorTracer).TraceContext().B3Headers() {
+		req.Header[k] = v
+	}
+	fmt.Println(req.Header.Get("X-B3-TraceId"), req.Header.Get("X-B3-SpanId"), req.Header.Get("X-B3-Sampled"))
+
+	// Output:
+	// 80f198ee56343ba864fe8b2a57d3eff7 e457b5a2e4d86bd1 1
+}
@@ -0,0 +1,127 @@
+package errors_test
+
+import (
+	"context"
+	stderr "errors"
+	"fmt"
+	"testing"
+
+	"github.com/bzon/errors"
+	pkgerrors "github.com/pkg/errors"
+	"go.opencensus.io/trace"
+)
+
+var benchErr error
+
+func BenchmarkNew(b *testing.B) {
+	b.Run("errors", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchErr = errors.New("a")
+		}
+	})
+	b.Run("stdlib", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchErr = stderr.New("a")
+		}
+	})
+	b.Run("pkg/errors", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchErr = pkgerrors.New("a")
+		}
+	})
+}
+
+func BenchmarkWrap(b *testing.B) {
+	err := stderr.New("a")
+	b.Run("errors", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchErr = errors.Wrap(err, "b")
+		}
+	})
+	b.Run("stdlib", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchErr = fmt.Errorf("b: %w", err)
+		}
+	})
+	b.Run("pkg/errors", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchErr = pkgerrors.Wrap(err, "b")
+		}
+	})
+}
+
+func BenchmarkSourceLocation(b *testing.B) {
+	e := errors.New("a").(errors.ErrorTracer)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = e.SourceLocation()
+	}
+}
+
+func BenchmarkNew_lazy(b *testing.B) {
+	errors.Configure(errors.Options{LazySourceLocation: true})
+	defer errors.Configure(errors.Options{})
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchErr = errors.New("a")
+	}
+}
+
+func BenchmarkWrapf(b *testing.B) {
+	err := stderr.New("a")
+	b.Run("errors", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchErr = errors.Wrapf(err, "get user %d", 1)
+		}
+	})
+	b.Run("stdlib", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchErr = fmt.Errorf("get user %d: %w", 1, err)
+		}
+	})
+}
+
+func BenchmarkNewT(b *testing.B) {
+	for _, bb := range []struct {
+		name    string
+		sampler trace.Sampler
+	}{
+		{"sampled", trace.AlwaysSample()},
+		{"unsampled", trace.NeverSample()},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			_, span := trace.StartSpan(context.Background(), "bench", trace.WithSampler(bb.sampler))
+			defer span.End()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				benchErr = errors.NewT(span, "a")
+			}
+		})
+	}
+}
+
+func BenchmarkLogFields(b *testing.B) {
+	err := errors.Wrap(errors.NewO("a", errors.NotFound), "b")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = errors.LogFields(err)
+	}
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	err := errors.Wrap(errors.NewO("a", errors.NotFound, errors.Str("user", "jb")), "b")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = errors.Marshal(err)
+	}
+}
@@ -0,0 +1,115 @@
+package errors
+
+import (
+	"container/list"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// spanBudgetIdle is the duration after which the budget of a span that was not flushed is forgotten,
+// see Options.SpanAnnotationLimit.
+const spanBudgetIdle = 10 * time.Minute
+
+// maxSpanBudgets is the number of spans whose budgets are kept. When more spans annotate errors
+// without being flushed, the budgets of the least recently used ones are forgotten, so that the spans
+// that are never flushed, e.g. the spans of other libraries than OpenCensus, do not hold memory for spanBudgetIdle.
+const maxSpanBudgets = 10000
+
+// spanBudget counts the errors annotated and skipped on a span.
+type spanBudget struct {
+	key       TraceContext
+	annotated int
+	skipped   int
+	// last is the last skipped error, reported by the summary annotation.
+	last     *errorContext
+	lastSeen time.Time
+}
+
+var (
+	spanBudgetsMu sync.Mutex
+	spanBudgets   = map[TraceContext]*list.Element{}
+	// spanBudgetsLRU are the budgets of spanBudgets, most recently used first.
+	spanBudgetsLRU = list.New()
+)
+
+// resetSpanBudgets forgets the budgets of all spans.
+func resetSpanBudgets() {
+	spanBudgetsMu.Lock()
+	defer spanBudgetsMu.Unlock()
+	spanBudgets = map[TraceContext]*list.Element{}
+	spanBudgetsLRU.Init()
+}
+
+// spanBudgetKey returns the key of the budget of the span of tc, without its trace flags.
+func spanBudgetKey(tc TraceContext) TraceContext {
+	return TraceContext{TraceID: tc.TraceID, SpanID: tc.SpanID}
+}
+
+// spend reports whether e is annotated on the span of its trace context under limit.
+// The errors that are not annotated are counted for the summary annotation of FlushSpanAnnotations.
+func spend(e *errorContext, limit int, now time.Time) bool {
+	spanBudgetsMu.Lock()
+	defer spanBudgetsMu.Unlock()
+	key := spanBudgetKey(e.TraceContext())
+	var b *spanBudget
+	if el, ok := spanBudgets[key]; ok {
+		b = el.Value.(*spanBudget)
+		spanBudgetsLRU.MoveToFront(el)
+	} else {
+		b = &spanBudget{key: key}
+		spanBudgets[key] = spanBudgetsLRU.PushFront(b)
+	}
+	b.lastSeen = now
+	evictSpanBudgets(now)
+	if b.annotated < limit {
+		b.annotated++
+		return true
+	}
+	b.skipped++
+	b.last = e
+	return false
+}
+
+// evictSpanBudgets forgets the least recently used budgets beyond maxSpanBudgets,
+// and the budgets of the spans idle for spanBudgetIdle.
+func evictSpanBudgets(now time.Time) {
+	for el := spanBudgetsLRU.Back(); el != nil; el = spanBudgetsLRU.Back() {
+		b := el.Value.(*spanBudget)
+		if spanBudgetsLRU.Len() <= maxSpanBudgets && now.Sub(b.lastSeen) < spanBudgetIdle {
+			return
+		}
+		spanBudgetsLRU.Remove(el)
+		delete(spanBudgets, b.key)
+	}
+}
+
+// FlushSpanAnnotations forgets the annotation budget of the span of a, see Options.SpanAnnotationLimit,
+// and annotates a summary of the errors that were not annotated on it, if any:
+// their number, and the message, source location and code of the last one.
+// It is meant to be called right before the span ends, e.g. by EndSpan for OpenCensus spans.
+//
+//	defer span.End()
+//	defer errors.FlushSpanAnnotations(errotel.Span(span))
+func FlushSpanAnnotations(a SpanAnnotator) {
+	if a == nil {
+		return
+	}
+	key := spanBudgetKey(a.TraceContext())
+	spanBudgetsMu.Lock()
+	el, ok := spanBudgets[key]
+	if ok {
+		delete(spanBudgets, key)
+		spanBudgetsLRU.Remove(el)
+	}
+	spanBudgetsMu.Unlock()
+	if !ok {
+		return
+	}
+	b := el.Value.(*spanBudget)
+	if b.skipped == 0 {
+		return
+	}
+	msg := strconv.Itoa(b.skipped) + " more errors not annotated, last: " + RedactedMessage(b.last)
+	a.AnnotateError(msg, b.last.SourceLocation(), CodeOf(b.last))
+}
@@ -0,0 +1,67 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bzon/errors"
+	"go.opencensus.io/trace"
+)
+
+func ExampleEndSpan() {
+	errors.Configure(errors.Options{SpanAnnotationLimit: 2})
+	defer errors.Configure(errors.Options{})
+
+	r := &spanRecorder{}
+	trace.RegisterExporter(r)
+	defer trace.UnregisterExporter(r)
+
+	_, span := trace.StartSpan(context.Background(), "retry", trace.WithSampler(trace.AlwaysSample()))
+	for i := 1; i <= 500; i++ {
+		_ = errors.ErrorfT(span, "attempt %d: unavailable", i)
+	}
+	errors.EndSpan(span)
+
+	for _, a := range r.spans[0].Annotations {
+		fmt.Println(a.Message)
+	}
+
+	// Output:
+	// Error: attempt 1: unavailable
+	// Error: attempt 2: unavailable
+	// Error: 498 more errors not annotated, last: attempt 500: unavailable
+}
+
+func ExampleFlushSpanAnnotations_unflushed() {
+	errors.Configure(errors.Options{SpanAnnotationLimit: 1})
+	defer errors.Configure(errors.Options{})
+
+	r := &spanRecorder{}
+	trace.RegisterExporter(r)
+	defer trace.UnregisterExporter(r)
+
+	ctx := context.Background()
+	_, first := trace.StartSpan(ctx, "first", trace.WithSampler(trace.AlwaysSample()))
+	_ = errors.NewT(first, "a")
+	_ = errors.NewT(first, "b")
+	// The spans that are not flushed only keep their budget while they are among the most recently used.
+	for i := 0; i < 10000; i++ {
+		_, span := trace.StartSpan(ctx, "other", trace.WithSampler(trace.AlwaysSample()))
+		_ = errors.NewT(span, "c")
+		span.End()
+	}
+	_ = errors.NewT(first, "d")
+	first.End()
+
+	for _, s := range r.spans {
+		if s.Name == "first" {
+			for _, a := range s.Annotations {
+				fmt.Println(a.Message)
+			}
+		}
+	}
+
+	// Output:
+	// Error: a
+	// Error: d
+}
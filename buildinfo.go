@@ -0,0 +1,46 @@
+package errors
+
+import (
+	"runtime/debug"
+)
+
+const unknown = "UNKNOWN"
+
+// mainModule is the path of the main module, e.g. github.com/mycorp/app, see Options.ModuleRelativePaths.
+var mainModule string
+
+// The build information is read when the package is initialized, after the -ldflags overrides.
+var _ = readBuildInfo()
+
+// readBuildInfo fills VERSION and COMMIT from the build information embedded in the binary
+// when they are not set via -ldflags.
+// VERSION is the main module version, or the VCS commit time of a development build.
+// COMMIT is the VCS revision, suffixed with -dirty when the working tree had local modifications.
+// BRANCH is not part of the build information.
+// It also records the path of the main module.
+func readBuildInfo() bool {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return false
+	}
+	mainModule = info.Main.Path
+	settings := make(map[string]string, len(info.Settings))
+	for _, s := range info.Settings {
+		settings[s.Key] = s.Value
+	}
+	if VERSION == unknown {
+		switch {
+		case info.Main.Version != "" && info.Main.Version != "(devel)":
+			VERSION = info.Main.Version
+		case settings["vcs.time"] != "":
+			VERSION = settings["vcs.time"]
+		}
+	}
+	if COMMIT == unknown && settings["vcs.revision"] != "" {
+		COMMIT = settings["vcs.revision"]
+		if settings["vcs.modified"] == "true" {
+			COMMIT += "-dirty"
+		}
+	}
+	return true
+}
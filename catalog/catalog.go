@@ -0,0 +1,153 @@
+// Package catalog is a registry of the errors of an application.
+// An error is defined once, with its code, message and documentation, and registered by name.
+// Its instances are created by name, or from the definition, and match the definition with errors.Is.
+//
+//	var ErrUserNotFound = catalog.Register(catalog.Definition{
+//		Name:    "USER_NOT_FOUND",
+//		Code:    errors.NotFound,
+//		Message: "user %d not found",
+//	})
+//
+//	err := catalog.New("USER_NOT_FOUND", id)
+//	errors.Is(err, ErrUserNotFound) // true
+package catalog
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/bzon/errors"
+)
+
+// Definition defines an error of the catalog.
+type Definition struct {
+	// Name is the unique name of the error, e.g. USER_NOT_FOUND.
+	Name string
+	// Code is the code of the error. It is also its gRPC code.
+	Code errors.Code
+	// Message is the default message of the error.
+	// It is a fmt format when the error is created with arguments.
+	Message string
+	// HTTPStatus is the HTTP status of the error.
+	// By default the status is derived from the code, see errors.HTTPStatus.
+	HTTPStatus int
+	// Severity is the severity of the error.
+	// By default the severity is errors.SeverityError, see errors.SeverityOf.
+	Severity errors.Severity
+	// DocsURL is the URL of the documentation of the error.
+	// It is the type of the problem details of the instances, see errors.ToProblem.
+	DocsURL string
+	// UserMessage is the message shown to users, in DefaultLanguage, see UserMessage.
+	// It is a fmt format when the error is created with arguments.
+	// It is translated to other languages with SetUserMessage.
+	UserMessage string
+}
+
+// instanceDepth is the caller depth of the source location of the instances,
+// from errors.WithO called by Definition.new, called by New or Definition.New.
+const instanceDepth = 4
+
+var (
+	mu          sync.RWMutex
+	definitions = map[string]*Definition{}
+)
+
+// Register registers the definition d of an error and returns it.
+// It panics if d has no name or if an error with the same name is already registered.
+func Register(d Definition) *Definition {
+	if d.Name == "" {
+		panic("catalog: error definition without name")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := definitions[d.Name]; ok {
+		panic("catalog: error " + d.Name + " registered twice")
+	}
+	definitions[d.Name] = &d
+	if d.UserMessage != "" {
+		_ = messages.SetString(DefaultLanguage, d.Name, d.UserMessage)
+	}
+	return &d
+}
+
+// Lookup returns the definition of the error registered with name.
+func Lookup(name string) (*Definition, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	d, ok := definitions[name]
+	return d, ok
+}
+
+// New creates an instance of the error registered with name, with the source location of the caller.
+// The message is the message of the definition formatted with args.
+// If no error is registered with name, it returns an Internal error.
+func New(name string, args ...interface{}) error {
+	d, ok := Lookup(name)
+	if !ok {
+		// The caller of New is at depth 3 from errors.NewO.
+		return errors.NewO("catalog: unknown error "+name, errors.Internal, errors.WithDepth(3))
+	}
+	return d.new(0, args)
+}
+
+// New creates an instance of d, with the source location of the caller.
+// The message is the message of d formatted with args.
+func (d *Definition) New(args ...interface{}) error {
+	return d.new(0, args)
+}
+
+// NewCaller is New with a specified caller depth, like errors.NewCaller.
+// It is meant for the typed constructors of the definitions, e.g. generated by cmd/errgen.
+func (d *Definition) NewCaller(depth int, args ...interface{}) error {
+	return d.new(depth-2, args)
+}
+
+// new creates an instance of d, with the source location of the caller of New, or skip frames above.
+func (d *Definition) new(skip int, args []interface{}) error {
+	m := d.Message
+	if len(args) > 0 {
+		m = fmt.Sprintf(m, args...)
+	}
+	return errors.WithO(&instance{definition: d, message: m, args: args},
+		d.Code, d.Severity, errors.WithStatusCode(d.HTTPStatus), errors.WithDepth(instanceDepth+skip))
+}
+
+// Error returns the name of d. It makes d a target of errors.Is.
+func (d *Definition) Error() string {
+	return d.Name
+}
+
+// DefinitionOf returns the definition of the instance in the chain of err, if any.
+func DefinitionOf(err error) (*Definition, bool) {
+	var i *instance
+	if !errors.As(err, &i) {
+		return nil, false
+	}
+	return i.definition, true
+}
+
+// instance is the root error of the instances of a definition.
+type instance struct {
+	definition *Definition
+	message    string
+	args       []interface{}
+}
+
+func (i *instance) Error() string {
+	return i.message
+}
+
+// ProblemType returns the documentation URL of the definition of i, the type of its problem details.
+func (i *instance) ProblemType() string {
+	return i.definition.DocsURL
+}
+
+// Is reports whether target is the definition of i, or another instance of it.
+// The instances match whatever their messages, source locations and trace contexts.
+func (i *instance) Is(target error) bool {
+	if target == i.definition {
+		return true
+	}
+	d, ok := DefinitionOf(target)
+	return ok && d == i.definition
+}
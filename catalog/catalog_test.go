@@ -0,0 +1,86 @@
+package catalog_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/catalog"
+)
+
+var ErrUserNotFound = catalog.Register(catalog.Definition{
+	Name:     "USER_NOT_FOUND",
+	Code:     errors.NotFound,
+	Message:  "user %d not found",
+	Severity: errors.SeverityWarning,
+	DocsURL:  "https://example.com/errors/user-not-found",
+})
+
+func ExampleNew() {
+	err := catalog.New("USER_NOT_FOUND", 1)
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, ErrUserNotFound))
+	fmt.Println(errors.CodeOf(err), errors.HTTPStatus(err), errors.SeverityOf(err))
+	fmt.Println(err.(errors.ErrorTracer).SourceLocation().Function)
+
+	fmt.Println(catalog.New("UNKNOWN_ERROR"))
+
+	// Output:
+	// user 1 not found
+	// true
+	// NOT_FOUND 404 WARNING
+	// github.com/bzon/errors/catalog_test.ExampleNew
+	// catalog: unknown error UNKNOWN_ERROR
+}
+
+func ExampleDefinition_New() {
+	err := errors.Wrap(ErrUserNotFound.New(2), "get user")
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, ErrUserNotFound))
+	if d, ok := catalog.DefinitionOf(err); ok {
+		fmt.Println(d.Name, d.DocsURL)
+	}
+
+	// Output:
+	// get user: user 2 not found
+	// true
+	// USER_NOT_FOUND https://example.com/errors/user-not-found
+}
+
+func ExampleDefinition_New_problem() {
+	err := ErrUserNotFound.New(2)
+	p := errors.ToProblem(err)
+	fmt.Println(p.Type, p.Status)
+	// The instance is wrapped once.
+	fmt.Println(len(errors.FramesOf(err)))
+
+	// Output:
+	// https://example.com/errors/user-not-found 404
+	// 1
+}
+
+func Example_is() {
+	errUserNotFound := ErrUserNotFound.New(0)
+
+	err := errors.Wrap(catalog.New("USER_NOT_FOUND", 3), "get user")
+	fmt.Println(errors.Is(err, errUserNotFound))
+	fmt.Println(errors.Is(err, catalog.New("UNKNOWN_ERROR")))
+
+	// Output:
+	// true
+	// false
+}
+
+// errUserNotFound is a typed constructor, like the ones generated by cmd/errgen.
+func errUserNotFound(id int) error {
+	return ErrUserNotFound.NewCaller(3, id)
+}
+
+func ExampleDefinition_NewCaller() {
+	err := errUserNotFound(4)
+	fmt.Println(err)
+	fmt.Println(err.(errors.ErrorTracer).SourceLocation().Function)
+
+	// Output:
+	// user 4 not found
+	// github.com/bzon/errors/catalog_test.ExampleDefinition_NewCaller
+}
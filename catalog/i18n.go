@@ -0,0 +1,47 @@
+package catalog
+
+import (
+	"github.com/bzon/errors"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	xcatalog "golang.org/x/text/message/catalog"
+)
+
+// DefaultLanguage is the language of the user messages of the definitions.
+// UserMessage falls back to it when the message of an error is not translated.
+var DefaultLanguage = language.English
+
+// messages are the user messages of the definitions by language, keyed by definition name.
+var messages = xcatalog.NewBuilder(xcatalog.Fallback(DefaultLanguage))
+
+// SetUserMessage sets the user message of the error registered with name in the language lang.
+// The message is a fmt format like Definition.UserMessage.
+func SetUserMessage(lang language.Tag, name, msg string) error {
+	if _, ok := Lookup(name); !ok {
+		return errors.New("catalog: unknown error " + name)
+	}
+	return messages.SetString(lang, name, msg)
+}
+
+// UserMessage returns the message of err to show to users in the language lang, unlike err.Error()
+// that is the technical message for logs.
+// It is the user message of the definition of the instance in the chain of err, formatted with the
+// arguments of the instance. The message falls back to DefaultLanguage when it is not translated to lang.
+// When err is not an instance or its definition has no user message, it is the public message of err,
+// see errors.PublicMessage.
+//
+//	tags, _, _ := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+//	msg := catalog.UserMessage(err, tags[0])
+func UserMessage(err error, lang language.Tag) string {
+	var i *instance
+	if errors.As(err, &i) && i.definition.UserMessage != "" {
+		name := i.definition.Name
+		for _, tag := range []language.Tag{lang, DefaultLanguage} {
+			// The printer returns the key when the message is missing.
+			if m := message.NewPrinter(tag, message.Catalog(messages)).Sprintf(name, i.args...); m != name {
+				return m
+			}
+		}
+	}
+	return errors.PublicMessage(err)
+}
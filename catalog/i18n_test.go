@@ -0,0 +1,34 @@
+package catalog_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/catalog"
+	"golang.org/x/text/language"
+)
+
+var ErrQuotaExceeded = catalog.Register(catalog.Definition{
+	Name:        "QUOTA_EXCEEDED",
+	Code:        errors.ResourceExhausted,
+	Message:     "project %s: quota of %d requests exceeded",
+	UserMessage: "You exceeded your quota of %[2]d requests.",
+})
+
+func ExampleUserMessage() {
+	_ = catalog.SetUserMessage(language.German, "QUOTA_EXCEEDED", "Sie haben Ihr Kontingent von %[2]d Anfragen überschritten.")
+
+	err := ErrQuotaExceeded.New("my-project", 100)
+	fmt.Println(err)
+	fmt.Println(catalog.UserMessage(err, language.English))
+	fmt.Println(catalog.UserMessage(err, language.MustParse("de-CH")))
+	fmt.Println(catalog.UserMessage(err, language.French))
+	fmt.Println(catalog.UserMessage(errors.New("connection reset"), language.German))
+
+	// Output:
+	// project my-project: quota of 100 requests exceeded
+	// You exceeded your quota of 100 requests.
+	// Sie haben Ihr Kontingent von 100 Anfragen überschritten.
+	// You exceeded your quota of 100 requests.
+	// Internal Server Error
+}
@@ -0,0 +1,130 @@
+package errors
+
+import (
+	"errors"
+	"reflect"
+	"sync/atomic"
+)
+
+// DefaultMaxChainDepth is the default of Options.MaxChainDepth.
+const DefaultMaxChainDepth = 1000
+
+// chainTruncated replaces the messages of the causes of the errors whose chain is deeper than
+// Options.MaxChainDepth, but the message of the innermost one.
+const chainTruncated = "[chain truncated]"
+
+// chainDepthLimit is Options.MaxChainDepth, read without copying the options on every walk.
+var chainDepthLimit atomic.Int64
+
+// maxChainDepth returns Options.MaxChainDepth, or DefaultMaxChainDepth.
+func maxChainDepth() int {
+	if n := chainDepthLimit.Load(); n > 0 {
+		return int(n)
+	}
+	return DefaultMaxChainDepth
+}
+
+// isWrap reports whether err counts as a wrap of the depth of a chain, see Options.MaxChainDepth.
+// The errors of this package do not: they carry the error they annotate, e.g. the one of Wrap.
+func isWrap(err error) bool {
+	_, ok := err.(*errorContext)
+	return !ok
+}
+
+// chainCycle detects a cycle in a chain of errors, e.g. an error that wraps itself, without allocating.
+// It is Brent's algorithm: every error is compared with a mark, that moves to the current error
+// after a growing number of steps, so that a cycle is detected within a few times its length.
+// The zero value is ready to use.
+type chainCycle struct {
+	mark       error
+	comparable bool
+	steps      int
+	power      int
+}
+
+// seen reports whether err is the mark, that is whether the chain loops back to an error it already had.
+func (c *chainCycle) seen(err error) bool {
+	// Comparing errors of a type that is not comparable would panic, like in errors.Is.
+	if c.comparable && err == c.mark {
+		return true
+	}
+	c.steps++
+	if c.steps > c.power {
+		c.mark = err
+		c.comparable = reflect.TypeOf(err).Comparable()
+		c.power = 2*c.power + 1
+		c.steps = 0
+	}
+	return false
+}
+
+// chainDepthAtLeast reports whether the chain of err, following the first error of the multi-errors,
+// has at least n wraps, see isWrap. A chain with a cycle has as many wraps as its distinct errors.
+func chainDepthAtLeast(err error, n int) bool {
+	var cycle chainCycle
+	for ; err != nil && n > 0 && !cycle.seen(err); err = unwrapFirst(err) {
+		if isWrap(err) {
+			n--
+		}
+	}
+	return n <= 0
+}
+
+// unwrapFirst returns the error wrapped by err, or the first error of a multi-error, or nil.
+func unwrapFirst(err error) error {
+	if m, ok := err.(interface{ Unwrap() []error }); ok {
+		if errs := m.Unwrap(); len(errs) > 0 {
+			return errs[0]
+		}
+		return nil
+	}
+	return errors.Unwrap(err)
+}
+
+// Walk calls fn for every error in the chain of err, outermost first, until fn returns false.
+// Both the errors wrapped with Unwrap() error and the multi-errors wrapped with Unwrap() []error,
+// e.g. by Join, are walked, the latter depth-first.
+// A chain that loops back to an error it already had, e.g. an error that wraps itself, is walked once,
+// and at most Options.MaxChainDepth wraps are walked, so that Walk ends on the pathological chains.
+func Walk(err error, fn func(error) bool) {
+	walk(err, func(err error) bool {
+		return !fn(err)
+	})
+}
+
+// Chain returns every error in the chain of err, in the order of Walk.
+func Chain(err error) []error {
+	var chain []error
+	walk(err, func(err error) bool {
+		chain = append(chain, err)
+		return false
+	})
+	return chain
+}
+
+// Cause returns the innermost error in the chain of err, for compatibility with github.com/pkg/errors.
+// It unwraps the errors of this package, the errors wrapped with Unwrap() error or with
+// Cause() error, as github.com/pkg/errors does, and the first error of multi-errors.
+// A chain that loops back to an error it already had is followed once.
+// It returns nil for a nil error.
+func Cause(err error) error {
+	var cycle chainCycle
+	for err != nil {
+		var next error
+		switch e := err.(type) {
+		case interface{ Unwrap() []error }:
+			if errs := e.Unwrap(); len(errs) > 0 {
+				next = errs[0]
+			}
+		case interface{ Unwrap() error }:
+			next = e.Unwrap()
+		case interface{ Cause() error }:
+			next = e.Cause()
+		}
+		if next == nil || cycle.seen(next) {
+			return err
+		}
+		err = next
+	}
+	return nil
+}
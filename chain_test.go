@@ -0,0 +1,108 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+	pkgerrors "github.com/pkg/errors"
+)
+
+func ExampleWalk() {
+	err := errors.Join(
+		errors.WithCode(errors.New("user not found"), errors.NotFound),
+		errors.Wrap(errSentinel, "get user"),
+	)
+
+	errors.Walk(err, func(err error) bool {
+		if e, ok := err.(errors.ErrorTracer); ok {
+			fmt.Printf("%q %s\n", e, errors.CodeOf(e))
+		}
+		return true
+	})
+
+	// Output:
+	// "user not found\nget user: sentinel error" NOT_FOUND
+	// "user not found" NOT_FOUND
+	// "get user: sentinel error" UNKNOWN
+}
+
+func ExampleChain() {
+	err := errors.Wrap(fmt.Errorf("query: %w", errSentinel), "get user")
+	for _, err := range errors.Chain(err) {
+		_, ok := err.(errors.ErrorTracer)
+		fmt.Printf("%q %t\n", err, ok)
+	}
+
+	// Output:
+	// "get user: query: sentinel error" true
+	// "get user: query: sentinel error" false
+	// "query: sentinel error" false
+	// "sentinel error" false
+}
+
+func ExampleCause_join() {
+	err := errors.Join(fmt.Errorf("query: %w", errSentinel), errors.New("b"))
+	fmt.Println(errors.Cause(err) == errSentinel)
+	fmt.Println(errors.Cause(nil))
+
+	// Output:
+	// true
+	// <nil>
+}
+
+func ExampleCause_pkgErrors() {
+	err := errors.Wrap(pkgerrors.Wrap(errSentinel, "query"), "get user")
+	fmt.Println(errors.Cause(err) == errSentinel)
+
+	// Output:
+	// true
+}
+
+// loopError is an error that wraps itself.
+type loopError struct{}
+
+func (e *loopError) Error() string { return "loop" }
+func (e *loopError) Unwrap() error { return e }
+
+// cycleError is an error of a chain that loops back to it.
+type cycleError struct{ next error }
+
+func (e *cycleError) Error() string { return "cycle" }
+func (e *cycleError) Unwrap() error { return e.next }
+
+func ExampleOptions_maxChainDepth() {
+	errors.Configure(errors.Options{MaxChainDepth: 3})
+	defer errors.Configure(errors.Options{})
+
+	fmt.Println(len(errors.Chain(&loopError{})))
+	a := &cycleError{}
+	a.next = &cycleError{next: errors.Wrap(a, "wrap")}
+	fmt.Println(len(errors.Chain(a)))
+
+	err := errors.New("root")
+	for i := 1; i <= 4; i++ {
+		err = errors.Wrapf(err, "layer %d", i)
+	}
+	fmt.Println(err)
+	fmt.Println(errors.Cause(err))
+
+	// Output:
+	// 1
+	// 4
+	// layer 4: [chain truncated]: root
+	// root
+}
+
+func ExampleCause_deep() {
+	root := errors.NewO("root", errors.NotFound)
+	err := root
+	for i := 1; i <= 200; i++ {
+		err = errors.Wrapf(err, "layer %d", i)
+	}
+	fmt.Println(errors.CodeOf(err))
+	fmt.Println(errors.Cause(err) == errors.Cause(root))
+
+	// Output:
+	// NOT_FOUND
+	// true
+}
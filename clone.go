@@ -0,0 +1,50 @@
+package errors
+
+// Clone returns a copy of the outermost layer of err, when it is created by this package,
+// that can be modified with SetTraceContext and SetSourceLocation without affecting err.
+// Other errors are returned as is.
+func Clone(err error) error {
+	if e, ok := err.(*errorContext); ok {
+		return e.clone()
+	}
+	return err
+}
+
+// WithSpanContext returns a copy of err with the trace context of sc.
+// Unlike SetTraceContext, it is safe to use on shared errors.
+// If err is not created by this package, it is wrapped with the source location of the caller.
+// It returns nil if err is nil.
+func WithSpanContext(err error, sc SpanContext) error {
+	if err == nil {
+		return nil
+	}
+	e := withContext(err, wrappedFunctionCallDepth)
+	e.traceContext = newTraceContext(sc)
+	return e
+}
+
+// WithTrace returns a copy of err with the trace context tc,
+// e.g. parsed by ParseXRayHeader or TraceContextFromB3.
+// If err is not created by this package, it is wrapped with the source location of the caller.
+// It returns nil if err is nil.
+func WithTrace(err error, tc TraceContext) error {
+	if err == nil {
+		return nil
+	}
+	e := withContext(err, wrappedFunctionCallDepth)
+	e.traceContext = tc
+	return e
+}
+
+// WithCallerLocation returns a copy of err with the source location at the caller depth,
+// like SetSourceLocation.
+// Unlike SetSourceLocation, it is safe to use on shared errors.
+// It returns nil if err is nil.
+func WithCallerLocation(err error, depth int) error {
+	if err == nil {
+		return nil
+	}
+	e := withContext(err, wrappedFunctionCallDepth)
+	e.sourceLocation = captureLocation(depth)
+	return e
+}
@@ -0,0 +1,63 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bzon/errors"
+	"go.opencensus.io/trace"
+)
+
+var errTimeout = errors.New("timeout")
+
+func ExampleWithSpanContext() {
+	err := errors.WithSpanContext(errTimeout, trace.SpanContext{
+		TraceID: [16]byte{'a', 'b', 'c'},
+		SpanID:  [8]byte{'d', 'e', 'f'},
+	})
+
+	fmt.Println(err.(errors.ErrorTracer).TraceContext().SpanID)
+	fmt.Println(errTimeout.(errors.ErrorTracer).TraceContext().SpanID == "")
+	fmt.Println(errors.Is(err, errTimeout))
+
+	// Output:
+	// 6465660000000000
+	// true
+	// true
+}
+
+func ExampleWithTrace() {
+	tc, _ := errors.ParseXRayHeader("Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1")
+	err := errors.WithTrace(io.EOF, tc)
+
+	e := err.(errors.ErrorTracer)
+	fmt.Println(e.TraceContext().TraceParent())
+	fmt.Println(e.SourceLocation().Function)
+
+	// Output:
+	// 00-5759e988bd862e3fe1be46a994272793-53995c3f42cd8ad8-01
+	// github.com/bzon/errors_test.ExampleWithTrace
+}
+
+func ExampleWithCallerLocation() {
+	err := errors.WithCallerLocation(errTimeout, 2)
+
+	fmt.Println(err.(errors.ErrorTracer).SourceLocation().Function)
+	fmt.Println(errTimeout.(errors.ErrorTracer).SourceLocation().Function)
+
+	// Output:
+	// github.com/bzon/errors_test.ExampleWithCallerLocation
+	// github.com/bzon/errors_test.init
+}
+
+func ExampleClone() {
+	err := errors.Clone(errTimeout)
+	err.(errors.ErrorTracer).SetSourceLocation(2)
+
+	fmt.Println(err.(errors.ErrorTracer).SourceLocation().Function)
+	fmt.Println(errTimeout.(errors.ErrorTracer).SourceLocation().Function)
+
+	// Output:
+	// github.com/bzon/errors_test.ExampleClone
+	// github.com/bzon/errors_test.init
+}
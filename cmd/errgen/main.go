@@ -0,0 +1,219 @@
+// Command errgen generates typed constructors for the errors of a YAML or JSON catalog.
+// The errors are registered in the catalog package, see github.com/bzon/errors/catalog.
+//
+//	//go:generate go run github.com/bzon/errors/cmd/errgen -in errors.yaml -out errors_gen.go
+//
+// The catalog file lists the errors of a package:
+//
+//	package: users
+//	errors:
+//	  - name: USER_NOT_FOUND
+//	    code: NOT_FOUND
+//	    message: user %s not found
+//	    args: [id string]
+//	    severity: WARNING
+//	    docs_url: https://example.com/errors/user-not-found
+//	    description: the user does not exist.
+//
+// Every error gets a definition, e.g. DefUserNotFound, and a constructor, e.g. ErrUserNotFound(id string) error.
+// The name of the constructor can be set with func.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File is a catalog file.
+type File struct {
+	Package string  `yaml:"package" json:"package"`
+	Errors  []Error `yaml:"errors" json:"errors"`
+}
+
+// Error is the definition of an error of a catalog file.
+type Error struct {
+	Name        string   `yaml:"name" json:"name"`
+	Func        string   `yaml:"func" json:"func"`
+	Code        string   `yaml:"code" json:"code"`
+	Message     string   `yaml:"message" json:"message"`
+	Args        []string `yaml:"args" json:"args"`
+	HTTPStatus  int      `yaml:"http_status" json:"http_status"`
+	Severity    string   `yaml:"severity" json:"severity"`
+	DocsURL     string   `yaml:"docs_url" json:"docs_url"`
+	UserMessage string   `yaml:"user_message" json:"user_message"`
+	Description string   `yaml:"description" json:"description"`
+}
+
+// codes are the identifiers of the code names.
+var codes = map[string]string{
+	"OK":                  "OK",
+	"CANCELLED":           "Canceled",
+	"UNKNOWN":             "Unknown",
+	"INVALID_ARGUMENT":    "InvalidArgument",
+	"DEADLINE_EXCEEDED":   "DeadlineExceeded",
+	"NOT_FOUND":           "NotFound",
+	"ALREADY_EXISTS":      "AlreadyExists",
+	"PERMISSION_DENIED":   "PermissionDenied",
+	"RESOURCE_EXHAUSTED":  "ResourceExhausted",
+	"FAILED_PRECONDITION": "FailedPrecondition",
+	"ABORTED":             "Aborted",
+	"OUT_OF_RANGE":        "OutOfRange",
+	"UNIMPLEMENTED":       "Unimplemented",
+	"INTERNAL":            "Internal",
+	"UNAVAILABLE":         "Unavailable",
+	"DATA_LOSS":           "DataLoss",
+	"UNAUTHENTICATED":     "Unauthenticated",
+}
+
+// severities are the identifiers of the severity names.
+var severities = map[string]string{
+	"DEFAULT":  "SeverityDefault",
+	"DEBUG":    "SeverityDebug",
+	"INFO":     "SeverityInfo",
+	"WARNING":  "SeverityWarning",
+	"ERROR":    "SeverityError",
+	"CRITICAL": "SeverityCritical",
+}
+
+func main() {
+	in := flag.String("in", "errors.yaml", "catalog `file`, YAML or JSON")
+	out := flag.String("out", "", "generated `file`, standard output by default")
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("errgen: ")
+
+	b, err := os.ReadFile(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(filepath.Base(*in), b)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(src)
+	} else {
+		err = os.WriteFile(*out, src, 0o644)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the Go source of the catalog file name with contents b.
+func generate(name string, b []byte) ([]byte, error) {
+	var f File
+	var err error
+	if strings.HasSuffix(name, ".json") {
+		err = json.Unmarshal(b, &f)
+	} else {
+		err = yaml.Unmarshal(b, &f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if f.Package == "" {
+		return nil, fmt.Errorf("%s: no package", name)
+	}
+
+	type constructor struct {
+		Error
+		Ident, Func, Code, Severity, Params, Args string
+	}
+	var cs []constructor
+	for _, e := range f.Errors {
+		c := constructor{Error: e, Ident: ident(e.Name), Func: e.Func}
+		if c.Func == "" {
+			c.Func = "Err" + c.Ident
+		}
+		var ok bool
+		if c.Code, ok = codes[e.Code]; !ok {
+			return nil, fmt.Errorf("%s: %s: unknown code %q", name, e.Name, e.Code)
+		}
+		if e.Severity != "" {
+			if c.Severity, ok = severities[e.Severity]; !ok {
+				return nil, fmt.Errorf("%s: %s: unknown severity %q", name, e.Name, e.Severity)
+			}
+		}
+		var params, args []string
+		for _, a := range e.Args {
+			fields := strings.Fields(a)
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("%s: %s: invalid arg %q, want <name> <type>", name, e.Name, a)
+			}
+			params = append(params, a)
+			args = append(args, fields[0])
+		}
+		c.Params = strings.Join(params, ", ")
+		c.Args = strings.Join(args, ", ")
+		cs = append(cs, c)
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, map[string]interface{}{
+		"Source":  name,
+		"Package": f.Package,
+		"Errors":  cs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// ident returns the Go identifier of an error name, e.g. UserNotFound for USER_NOT_FOUND.
+func ident(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' || r == '.' }) {
+		b.WriteString(strings.ToUpper(word[:1]) + strings.ToLower(word[1:]))
+	}
+	return b.String()
+}
+
+var tmpl = template.Must(template.New("").Parse(`// Code generated by errgen from {{.Source}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/catalog"
+)
+{{range .Errors}}
+// Def{{.Ident}} is the definition of the {{.Name}} error.
+var Def{{.Ident}} = catalog.Register(catalog.Definition{
+	Name:    {{printf "%q" .Name}},
+	Code:    errors.{{.Code}},
+	Message: {{printf "%q" .Message}},
+	{{- if .HTTPStatus}}
+	HTTPStatus: {{.HTTPStatus}},
+	{{- end}}
+	{{- if .Severity}}
+	Severity: errors.{{.Severity}},
+	{{- end}}
+	{{- if .DocsURL}}
+	DocsURL: {{printf "%q" .DocsURL}},
+	{{- end}}
+	{{- if .UserMessage}}
+	UserMessage: {{printf "%q" .UserMessage}},
+	{{- end}}
+})
+
+// {{.Func}} creates a {{.Name}} error{{if .Description}}: {{.Description}}{{else}}.{{end}}
+// Its code is {{.Error.Code}}{{if .HTTPStatus}} and its HTTP status {{.HTTPStatus}}{{end}}.
+{{- if .DocsURL}}
+// See {{.DocsURL}}.
+{{- end}}
+func {{.Func}}({{.Params}}) error {
+	return Def{{.Ident}}.NewCaller(3{{if .Args}}, {{.Args}}{{end}})
+}
+{{end}}`))
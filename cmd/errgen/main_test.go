@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// TestGenerate compares the source generated from testdata/errors.yaml with testdata/errors_gen.go.
+// Run go generate to update it.
+//
+//go:generate go run . -in testdata/errors.yaml -out testdata/errors_gen.go
+func TestGenerate(t *testing.T) {
+	in, err := os.ReadFile("testdata/errors.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile("testdata/errors_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := generate("errors.yaml", in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("generated source differs from testdata/errors_gen.go:\n%s", got)
+	}
+}
+
+func TestGenerate_errors(t *testing.T) {
+	for _, src := range []string{
+		`{"errors": []}`,
+		`{"package": "users", "errors": [{"name": "A", "code": "NOPE"}]}`,
+		`{"package": "users", "errors": [{"name": "A", "code": "INTERNAL", "severity": "LOUD"}]}`,
+		`{"package": "users", "errors": [{"name": "A", "code": "INTERNAL", "args": ["id"]}]}`,
+	} {
+		if _, err := generate("errors.json", []byte(src)); err == nil {
+			t.Errorf("generate(%s) succeeded, want an error", src)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+module github.com/bzon/errors/cmd/errlint
+
+go 1.22.0
+
+require (
+	github.com/bzon/errors/errlint v0.1.0
+	golang.org/x/tools v0.28.0
+)
+
+require (
+	golang.org/x/mod v0.22.0 // indirect
+	golang.org/x/sync v0.10.0 // indirect
+)
@@ -0,0 +1,14 @@
+// Command errlint checks the adoption of github.com/bzon/errors, see package errlint.
+//
+//	go install github.com/bzon/errors/cmd/errlint
+//	go vet -vettool=$(which errlint) ./...
+package main
+
+import (
+	"github.com/bzon/errors/errlint"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(errlint.Analyzer)
+}
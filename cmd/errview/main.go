@@ -0,0 +1,234 @@
+// Command errview pretty-prints the errors of JSON log lines, or of errors encoded by errors.Marshal,
+// read from the standard input, to triage them from the logs.
+//
+//	kubectl logs deploy/user-service | errview -project my-project -src ~/src/user-service
+//
+// Every error is printed with its severity and ID, its chain of causes, see errors.Tree,
+// the source code around its source locations, and the links to its trace and logs.
+// The log lines are the ones written with errors.LogFields, e.g. by errzap or errors.SlogAttrs,
+// and the lines with an errors.Marshal encoded error in their error field.
+// The other lines are skipped.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/errorspb"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Log entry keys, see errors.LogFields.
+const (
+	keyMessage        = "message"
+	keySeverity       = "severity"
+	keyError          = "error"
+	keyErrorID        = "errorId"
+	keySourceLocation = "logging.googleapis.com/sourceLocation"
+	keyTrace          = "logging.googleapis.com/trace"
+	keySpanID         = "logging.googleapis.com/spanId"
+)
+
+func main() {
+	project := flag.String("project", "", "Google Cloud project `ID` of the trace and logs links, read from the traces by default")
+	jaeger := flag.String("jaeger", "", "Jaeger UI `URL` of the trace links, e.g. http://localhost:16686")
+	src := flag.String("src", ".", "`directory` of the relative source files")
+	lines := flag.Int("lines", 3, "`number` of lines of source code printed around the source locations")
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("errview: ")
+
+	errors.Configure(errors.Options{
+		ProjectID:    *project,
+		JaegerURL:    *jaeger,
+		SnippetLines: *lines,
+	})
+	v := &viewer{src: *src, project: *project}
+	if err := v.run(os.Stdin, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// entry is an error read from the input.
+type entry struct {
+	err      error
+	severity string
+	id       string
+}
+
+// viewer prints the errors of log lines.
+type viewer struct {
+	// src is the directory of the relative source files.
+	src string
+	// project is the project ID set by the -project flag.
+	// The project IDs of the traces of the log entries are used when it is empty.
+	project string
+}
+
+// run prints the errors of the lines of r to w.
+func (v *viewer) run(r io.Reader, w io.Writer) error {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for s.Scan() {
+		e, ok := v.parse(s.Bytes())
+		if !ok {
+			continue
+		}
+		if _, err := io.WriteString(w, render(e)); err != nil {
+			return err
+		}
+	}
+	return s.Err()
+}
+
+// parse reads the error of line, a JSON log entry or an error encoded by errors.Marshal.
+// It reports false when line has no error.
+func (v *viewer) parse(line []byte) (entry, bool) {
+	line = bytes.TrimSpace(line)
+	var fields map[string]json.RawMessage
+	if len(line) == 0 || line[0] != '{' || json.Unmarshal(line, &fields) != nil {
+		return entry{}, false
+	}
+	if isMarshaled(fields) {
+		return v.parseMarshaled(line)
+	}
+	if raw, ok := fields[keyError]; ok && bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{")) {
+		e, ok := v.parseMarshaled(raw)
+		if ok {
+			e.severity = stringField(fields, keySeverity)
+		}
+		return e, ok
+	}
+	if _, ok := fields[keyErrorID]; !ok {
+		return entry{}, false
+	}
+	return v.parseLogEntry(fields), true
+}
+
+// isMarshaled reports whether fields are the fields of an errors.Marshal encoded error, rather than of a log entry.
+func isMarshaled(fields map[string]json.RawMessage) bool {
+	for _, k := range []string{"traced", "causes", "sourceLocation", "traceContext"} {
+		if _, ok := fields[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMarshaled decodes an error encoded by errors.Marshal.
+// The fields unknown to errorspb.Error are discarded.
+func (v *viewer) parseMarshaled(b []byte) (entry, bool) {
+	p := &errorspb.Error{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(b, p); err != nil || p.Message == "" {
+		return entry{}, false
+	}
+	v.resolveProto(p)
+	err := errors.FromProto(p)
+	e := entry{err: err, severity: errors.SeverityOf(err).String()}
+	if t, ok := errors.TracerOf(err); ok {
+		e.id = errors.IDOf(t)
+	}
+	return e, true
+}
+
+// resolveProto resolves the relative files of the source locations of p and its causes.
+func (v *viewer) resolveProto(p *errorspb.Error) {
+	if l := p.SourceLocation; l != nil {
+		l.File = v.resolve(l.File)
+	}
+	for _, l := range p.Stack {
+		l.File = v.resolve(l.File)
+	}
+	for _, cause := range p.Causes {
+		v.resolveProto(cause)
+	}
+}
+
+// parseLogEntry reads the error of a log entry written with errors.LogFields.
+func (v *viewer) parseLogEntry(fields map[string]json.RawMessage) entry {
+	opts := []errors.Option{errors.NoHooks()}
+	var loc struct {
+		Function string          `json:"function"`
+		File     string          `json:"file"`
+		Line     json.RawMessage `json:"line"`
+	}
+	if json.Unmarshal(fields[keySourceLocation], &loc) == nil && loc.File != "" {
+		// The logging agents may write the line as a string.
+		line, _ := strconv.Atoi(strings.Trim(string(loc.Line), `"`))
+		opts = append(opts, errors.WithSourceLocation(errors.SourceLocation{
+			Function: loc.Function,
+			File:     v.resolve(loc.File),
+			Line:     line,
+		}))
+	}
+	if trace := stringField(fields, keyTrace); trace != "" {
+		// The trace is projects/<PROJECT_ID>/traces/<TRACE_ID> when a project ID is set.
+		if project, id, ok := strings.Cut(strings.TrimPrefix(trace, "projects/"), "/traces/"); ok {
+			trace = id
+			if v.project == "" && project != "" && !strings.Contains(project, "/") {
+				errors.SetProjectID(project)
+			}
+		}
+		opts = append(opts, errors.WithTraceContext(errors.TraceContext{
+			TraceID: trace,
+			SpanID:  stringField(fields, keySpanID),
+		}))
+	}
+	message := stringField(fields, keyMessage)
+	if message == "" {
+		message = stringField(fields, "msg")
+	}
+	return entry{
+		err:      errors.NewO(message, opts...),
+		severity: stringField(fields, keySeverity),
+		id:       stringField(fields, keyErrorID),
+	}
+}
+
+// stringField returns the string field k of fields, or an empty string.
+func stringField(fields map[string]json.RawMessage, k string) string {
+	var s string
+	_ = json.Unmarshal(fields[k], &s)
+	return s
+}
+
+// resolve returns file joined to the source directory when it is relative.
+func (v *viewer) resolve(file string) string {
+	if file == "" || filepath.IsAbs(file) {
+		return file
+	}
+	return filepath.Join(v.src, file)
+}
+
+// render returns the text of e: a header with its severity and ID, its tree, its source locations
+// with the source code around them, and the links to its trace and logs.
+func render(e entry) string {
+	var b strings.Builder
+	header := strings.TrimSpace(e.severity + " " + e.id)
+	if header == "" {
+		header = "ERROR"
+	}
+	fmt.Fprintf(&b, "━━ %s\n%s\n", header, errors.Tree(e.err))
+	// The %+v format is the message followed by the frames and their snippets.
+	if frames := strings.TrimPrefix(fmt.Sprintf("%+v", e.err), e.err.Error()); frames != "" {
+		fmt.Fprintf(&b, "\nat:%s\n", frames)
+	}
+	if u := errors.TraceURL(e.err); u != "" {
+		fmt.Fprintf(&b, "\ntrace: %s\n", u)
+	}
+	if u := errors.LogsURL(e.err); u != "" {
+		fmt.Fprintf(&b, "logs:  %s\n", u)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/bzon/errors"
+)
+
+// TestRun compares the errors printed from testdata/input.log with testdata/output.txt.
+// Run go run . -src testdata < testdata/input.log > testdata/output.txt to update it.
+func TestRun(t *testing.T) {
+	errors.Configure(errors.Options{SnippetLines: 3})
+	defer errors.Configure(errors.Options{})
+
+	in, err := os.Open("testdata/input.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	want, err := os.ReadFile("testdata/output.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got bytes.Buffer
+	v := &viewer{src: "testdata"}
+	if err := v.run(in, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got.Bytes(), want) {
+		t.Errorf("output differs from testdata/output.txt:\n%s", got.Bytes())
+	}
+}
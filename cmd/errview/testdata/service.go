@@ -0,0 +1,9 @@
+package users
+
+func (s *Service) Get(id string) (*User, error) {
+	u, err := s.db.Find(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "get user")
+	}
+	return u, nil
+}
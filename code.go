@@ -0,0 +1,103 @@
+package errors
+
+import "strconv"
+
+// Code is a canonical error code.
+// The values are the gRPC codes, which are also the OpenCensus trace status codes.
+// See https://github.com/googleapis/googleapis/blob/master/google/rpc/code.proto.
+type Code int32
+
+// The canonical error codes.
+const (
+	OK                 Code = 0
+	Canceled           Code = 1
+	Unknown            Code = 2
+	InvalidArgument    Code = 3
+	DeadlineExceeded   Code = 4
+	NotFound           Code = 5
+	AlreadyExists      Code = 6
+	PermissionDenied   Code = 7
+	ResourceExhausted  Code = 8
+	FailedPrecondition Code = 9
+	Aborted            Code = 10
+	OutOfRange         Code = 11
+	Unimplemented      Code = 12
+	Internal           Code = 13
+	Unavailable        Code = 14
+	DataLoss           Code = 15
+	Unauthenticated    Code = 16
+)
+
+var codeNames = map[Code]string{
+	OK:                 "OK",
+	Canceled:           "CANCELLED",
+	Unknown:            "UNKNOWN",
+	InvalidArgument:    "INVALID_ARGUMENT",
+	DeadlineExceeded:   "DEADLINE_EXCEEDED",
+	NotFound:           "NOT_FOUND",
+	AlreadyExists:      "ALREADY_EXISTS",
+	PermissionDenied:   "PERMISSION_DENIED",
+	ResourceExhausted:  "RESOURCE_EXHAUSTED",
+	FailedPrecondition: "FAILED_PRECONDITION",
+	Aborted:            "ABORTED",
+	OutOfRange:         "OUT_OF_RANGE",
+	Unimplemented:      "UNIMPLEMENTED",
+	Internal:           "INTERNAL",
+	Unavailable:        "UNAVAILABLE",
+	DataLoss:           "DATA_LOSS",
+	Unauthenticated:    "UNAUTHENTICATED",
+}
+
+// String returns the canonical name of the code, e.g. NOT_FOUND.
+func (c Code) String() string {
+	if s, ok := codeNames[c]; ok {
+		return s
+	}
+	return "CODE(" + strconv.Itoa(int(c)) + ")"
+}
+
+// ParseCode returns the code of its canonical name, e.g. NOT_FOUND, see Code.String.
+func ParseCode(name string) (Code, bool) {
+	for c, s := range codeNames {
+		if s == name {
+			return c, true
+		}
+	}
+	return Unknown, false
+}
+
+// WithCode returns a copy of err that carries the code c.
+// If err is not created by this package, it is wrapped with the source location of the caller.
+func WithCode(err error, c Code) error {
+	if err == nil {
+		return nil
+	}
+	e := withContext(err, wrappedFunctionCallDepth)
+	e.code = c
+	return e
+}
+
+// CodeOf returns the code of the outermost error in the chain of err that has one.
+// When no error of the chain has a code, the code is inferred from the errors of other packages
+// in the chain, e.g. NotFound for sql.ErrNoRows, see Options.CodeRules.
+// It returns OK for a nil error and Unknown when no code is found.
+func CodeOf(err error) Code {
+	if err == nil {
+		return OK
+	}
+	c := OK
+	visit(err, func(e *errorContext) bool {
+		if e.code != OK {
+			c = e.code
+			return true
+		}
+		return false
+	})
+	if c == OK {
+		c = inferCode(err)
+	}
+	if c == OK {
+		return Unknown
+	}
+	return c
+}
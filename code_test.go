@@ -0,0 +1,36 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleWithCode() {
+	err := errors.WithCode(errors.New("user not found"), errors.NotFound)
+	err = errors.Wrap(err, "get user")
+	fmt.Println(err)
+	fmt.Println(errors.CodeOf(err))
+
+	// Output:
+	// get user: user not found
+	// NOT_FOUND
+}
+
+func ExampleCodeOf() {
+	fmt.Println(errors.CodeOf(nil))
+	fmt.Println(errors.CodeOf(errors.New("a")))
+
+	// Output:
+	// OK
+	// UNKNOWN
+}
+
+func ExampleParseCode() {
+	fmt.Println(errors.ParseCode("NOT_FOUND"))
+	fmt.Println(errors.ParseCode("MISSING"))
+
+	// Output:
+	// NOT_FOUND true
+	// UNKNOWN false
+}
@@ -0,0 +1,160 @@
+package errors
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Options are the package-global settings.
+type Options struct {
+	// ProjectID is the Google Cloud project ID.
+	// It is used to format traces as projects/<ProjectID>/traces/<TRACE_ID>.
+	ProjectID string
+
+	// JaegerURL is the base URL of the Jaeger UI, e.g. http://localhost:16686.
+	// TraceURL links to the Jaeger UI when it is set, and to the Google Cloud Console otherwise.
+	JaegerURL string
+
+	// ServiceName is the name of the service reporting the errors.
+	// It is logged as the serviceContext of Google Cloud Error Reporting.
+	ServiceName string
+
+	// Environment is the deployment environment, e.g. production.
+	// It is logged as the environment label.
+	Environment string
+
+	// LazySourceLocation defers the symbolization of source locations until they are first read.
+	// Source locations are symbolized once per call site and cached either way,
+	// this only saves the symbolization of call sites whose errors are never read.
+	LazySourceLocation bool
+
+	// TrimPathPrefix is trimmed from the files of the source locations,
+	// e.g. /builder/src/ to report github.com/mycorp/app/internal/user/service.go.
+	TrimPathPrefix string
+
+	// ModuleRelativePaths reports the files of the packages of the main module, read from the build information,
+	// relative to the module root, e.g. internal/user/service.go,
+	// whatever the directory the binary was built in.
+	// The files of other modules and of the main package are trimmed by TrimPathPrefix only.
+	ModuleRelativePaths bool
+
+	// SnippetLines is the number of lines of source code, before and after the source location of an error,
+	// that Snippet and the %+v format read from the local source file.
+	// It is meant for local development, where the source files are available, and is 0 by default.
+	SnippetLines int
+
+	// AppPackages are the import path prefixes of the packages of the application, e.g. github.com/mycorp/app,
+	// that tell the frames of the application from the frames of its dependencies, see AppFrames.
+	// The main package is always part of the application.
+	// By default, the application is the main module, or every package but the standard library
+	// when the main module is unknown.
+	AppPackages []string
+
+	// AppSourceLocation sets the source location of the errors with a stack, e.g. the recovered panics,
+	// to the first frame of the application in their stack, so that the frames of the helpers and the middlewares
+	// of the dependencies do not dominate the reports.
+	AppSourceLocation bool
+
+	// MaxChainDepth is the maximum number of wraps walked in the chain of an error, e.g. by Walk, CodeOf
+	// and FieldsOf, so that the pathological chains are not walked for ever. The errors of this package
+	// do not count, e.g. a Wrap is one wrap. The chains with a cycle, e.g. an error that wraps itself,
+	// are detected and walked once, whatever their depth, and Cause always returns the innermost error.
+	// The wrapping errors of a chain deeper than MaxChainDepth keep the message of its innermost error
+	// but replace the messages in between with a [chain truncated] marker, so that the messages do not grow unbounded.
+	// It is DefaultMaxChainDepth when zero.
+	MaxChainDepth int
+
+	// CallerSkip is added to the depth of all the captured source locations.
+	// It is meant for applications that wrap all the constructors of this package in a helper,
+	// see SkipPackage to skip only some helpers.
+	CallerSkip int
+
+	// DatadogFields adds the dd.trace_id and dd.span_id fields to LogFields,
+	// for the Datadog agent to correlate the logs with the traces.
+	DatadogFields bool
+
+	// ShouldAnnotate reports whether an error is annotated on its span.
+	// The trace context of the error is set either way.
+	// It is useful to skip expected errors, like cache misses, that would otherwise flag the span as failed.
+	// All errors are annotated when it is nil.
+	ShouldAnnotate func(err error) bool
+
+	// DeferAnnotation defers the annotation of the errors on their span until they are logged, reported
+	// or serialized, see AnnotateDeferred, so that the errors that are handled and swallowed do not flag
+	// their span as failed.
+	// The trace context of the errors is set when they are created either way.
+	// The errors are only annotated when they are logged before their span ends.
+	DeferAnnotation bool
+
+	// FieldAttributePrefix prefixes the keys of the fields of the errors annotated on spans
+	// whose SpanAnnotator is a FieldsAnnotator, DefaultFieldAttributePrefix by default.
+	FieldAttributePrefix string
+
+	// DefaultFields are added to the fields of every error, see FieldsOf.
+	// The fields of the errors take precedence.
+	DefaultFields Fields
+
+	// EnvironmentFields adds EnvironmentFields, read once by Configure, to DefaultFields,
+	// so that the logs identify the workload instance, e.g. the Kubernetes pod or the Cloud Run revision.
+	// DefaultFields take precedence.
+	EnvironmentFields bool
+
+	// CaptureRuntime captures the ID of the goroutine, GOOS, GOARCH, the Go version, the hostname
+	// and the Kubernetes pod name with the errors, see Runtime.
+	// They are logged by LogFields and kept by Marshal, to debug issues that only occur on some nodes.
+	// It costs a stack trace header per error, and is false by default.
+	CaptureRuntime bool
+
+	// AnnotateLimit limits the errors of the same fingerprint annotated on spans,
+	// so that a failing dependency does not blow up the size of the traces.
+	// The trace context of the errors is set either way, and the hooks are called with all of them.
+	// The fingerprints are forgotten once their window is over, and at most 10000 are tracked at once:
+	// the errors of the others are annotated.
+	// The errors are not limited when it is zero.
+	AnnotateLimit AnnotateLimit
+
+	// SpanAnnotationLimit is the number of errors annotated per span, so that a retry loop
+	// that creates hundreds of errors in one span keeps the trace readable.
+	// The others are counted, and summarized in one annotation by FlushSpanAnnotations or EndSpan.
+	// The errors are not limited when it is zero.
+	SpanAnnotationLimit int
+
+	// CodeRules infer the codes of the errors wrapped without one from their causes,
+	// e.g. NotFound for sql.ErrNoRows, see CodeOf. The first rule that knows a cause is used.
+	// The DefaultCodeRules are used when it is nil, and no code is inferred when it is empty.
+	CodeRules []CodeRule
+}
+
+var (
+	options   atomic.Value
+	optionsMu sync.Mutex
+)
+
+// Configure sets the package-global options.
+func Configure(o Options) {
+	optionsMu.Lock()
+	defer optionsMu.Unlock()
+	if o.EnvironmentFields {
+		o.DefaultFields = EnvironmentFields().merge(o.DefaultFields)
+	}
+	options.Store(o)
+	chainDepthLimit.Store(int64(o.MaxChainDepth))
+	setCodeRules(o.CodeRules)
+	resetSampler()
+	resetSpanBudgets()
+}
+
+// SetProjectID sets the Google Cloud project ID of the package-global options.
+func SetProjectID(id string) {
+	optionsMu.Lock()
+	defer optionsMu.Unlock()
+	o := config()
+	o.ProjectID = id
+	options.Store(o)
+}
+
+// config returns the package-global options.
+func config() Options {
+	o, _ := options.Load().(Options)
+	return o
+}
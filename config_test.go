@@ -0,0 +1,53 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bzon/errors"
+	"go.opencensus.io/trace"
+)
+
+func ExampleConfigure() {
+	errors.Configure(errors.Options{
+		ProjectID:   "my-project",
+		ServiceName: "user-service",
+		Environment: "production",
+	})
+	defer errors.Configure(errors.Options{})
+
+	fields := errors.LogFieldsMap(errors.New("a"))
+	fmt.Println(fields["serviceContext"].(errors.ServiceContext).Service)
+	fmt.Println(fields["logging.googleapis.com/labels"])
+
+	// Output:
+	// user-service
+	// map[environment:production]
+}
+
+var errCacheMiss = errors.New("cache miss")
+
+func ExampleOptions_shouldAnnotate() {
+	errors.Configure(errors.Options{
+		ShouldAnnotate: func(err error) bool {
+			return !errors.Is(err, errCacheMiss)
+		},
+	})
+	defer errors.Configure(errors.Options{})
+
+	r := &spanRecorder{}
+	trace.RegisterExporter(r)
+	defer trace.UnregisterExporter(r)
+
+	_, span := trace.StartSpan(context.Background(), "foo", trace.WithSampler(trace.AlwaysSample()))
+	_ = errors.WrapT(span, errCacheMiss, "get user")
+	_ = errors.NewT(span, "a")
+	span.End()
+
+	for _, a := range r.spans[0].Annotations {
+		fmt.Println(a.Message)
+	}
+
+	// Output:
+	// Error: a
+}
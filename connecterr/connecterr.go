@@ -0,0 +1,167 @@
+// Package connecterr converts errors to and from Connect errors.
+// The Connect errors carry the google.rpc.ErrorInfo, google.rpc.DebugInfo and google.rpc.LocalizedMessage details
+// of grpcerr.ToStatus, and the trace context of the errors as a traceparent metadata.
+//
+//	path, handler := examplev1connect.NewUserServiceHandler(svc, connect.WithInterceptors(connecterr.Interceptor()))
+package connecterr
+
+import (
+	"context"
+	stderrors "errors"
+
+	"connectrpc.com/connect"
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/grpcerr"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// MetaTraceParent is the metadata key of the W3C traceparent of the errors, see errors.TraceContext.TraceParent.
+const MetaTraceParent = "Traceparent"
+
+// Interceptor returns a unary Connect interceptor.
+// On handlers, it wraps the errors with the procedure name, annotates them on the span of the context
+// and converts them to Connect errors, see ToConnect. The errors keep the source location where they occurred,
+// see errors.AtOrigin.
+// On clients, it converts the Connect errors of calls back into ErrorTracer errors, see FromConnect.
+func Interceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			resp, err := next(ctx, req)
+			if err == nil {
+				return resp, nil
+			}
+			if req.Spec().IsClient {
+				return resp, FromError(err)
+			}
+			procedure := req.Spec().Procedure
+			if procedure == "" {
+				procedure = "connect"
+			}
+			return resp, ToConnect(errors.WrapO(err, procedure, errors.WithContext(ctx), errors.AtOrigin()))
+		}
+	}
+}
+
+// ToConnect converts err to a Connect error with the code, message and details of grpcerr.ToStatus.
+// When err has no public message and a Connect error is in its chain, e.g. the error of a handler
+// created with connect.NewError, the message, details and metadata of that Connect error are kept instead,
+// and its code when err has no code.
+// The trace context of the outermost ErrorTracer in the chain of err is added as the MetaTraceParent metadata.
+// Connect errors are returned as is. It returns nil for a nil error.
+func ToConnect(err error) *connect.Error {
+	if err == nil {
+		return nil
+	}
+	if cerr, ok := err.(*connect.Error); ok {
+		return cerr
+	}
+	s := grpcerr.ToStatus(err)
+	cerr := baseError(err, s)
+	for _, d := range s.Details() {
+		m, ok := d.(proto.Message)
+		if !ok || hasDetail(cerr, m) {
+			continue
+		}
+		if info, ok := m.(*errdetails.ErrorInfo); ok && info.Domain == grpcerr.Domain {
+			info.Reason = errors.Code(cerr.Code()).String()
+		}
+		if detail, derr := connect.NewErrorDetail(m); derr == nil {
+			cerr.AddDetail(detail)
+		}
+	}
+	if e, ok := errors.TracerOf(err); ok {
+		if tp := e.TraceContext().TraceParent(); tp != "" {
+			cerr.Meta().Set(MetaTraceParent, tp)
+		}
+	}
+	return cerr
+}
+
+// baseError returns the Connect error that ToConnect adds the details of s to.
+// It is a copy of the first Connect error in the chain of err when err has no public message,
+// without the details and metadata of this package, since they describe the error of another service.
+// Otherwise, it is a new Connect error with the code and message of s.
+func baseError(err error, s *status.Status) *connect.Error {
+	native, ok := errors.AsType[*connect.Error](err)
+	if !ok || errors.HasPublicMessage(err) {
+		return connect.NewError(connect.Code(s.Code()), stderrors.New(s.Message()))
+	}
+	code := connect.Code(s.Code())
+	if errors.CodeOf(err) == errors.Unknown {
+		code = native.Code()
+	}
+	cerr := connect.NewError(code, stderrors.New(native.Message()))
+	for _, d := range native.Details() {
+		m, derr := d.Value()
+		if derr != nil {
+			cerr.AddDetail(d)
+			continue
+		}
+		switch m := m.(type) {
+		case *errdetails.ErrorInfo:
+			if m.Domain == grpcerr.Domain {
+				continue
+			}
+		case *errdetails.DebugInfo, *errdetails.LocalizedMessage:
+			continue
+		}
+		cerr.AddDetail(d)
+	}
+	for k, vs := range native.Meta() {
+		if k == MetaTraceParent {
+			continue
+		}
+		for _, v := range vs {
+			cerr.Meta().Add(k, v)
+		}
+	}
+	return cerr
+}
+
+// hasDetail reports whether the details of cerr have m, e.g. the details of a Connect error
+// that were restored as the details of an error by FromConnect.
+func hasDetail(cerr *connect.Error, m proto.Message) bool {
+	for _, d := range cerr.Details() {
+		if v, err := d.Value(); err == nil && proto.Equal(v, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// FromError converts a Connect error in the chain of err to an ErrorTracer error, see FromConnect.
+// The Connect error is kept in the chain of the error, so that errors.As still finds it.
+// Other errors are returned as is.
+func FromError(err error) error {
+	cerr, ok := errors.AsType[*connect.Error](err)
+	if !ok {
+		return err
+	}
+	return FromConnect(cerr)
+}
+
+// FromConnect converts a Connect error to an ErrorTracer error with its code and message.
+// The source location, trace context, ID, stack and public message are restored from the details
+// of Connect errors created by ToConnect, see grpcerr.FromStatus.
+// The Connect error is the cause of the error, so that errors.As still finds it.
+// It returns nil for a nil error.
+func FromConnect(cerr *connect.Error) error {
+	if cerr == nil {
+		return nil
+	}
+	s := &spb.Status{Code: int32(cerr.Code()), Message: cerr.Message()}
+	for _, d := range cerr.Details() {
+		m, err := d.Value()
+		if err != nil {
+			continue
+		}
+		if a, err := anypb.New(m); err == nil {
+			s.Details = append(s.Details, a)
+		}
+	}
+	return grpcerr.FromStatusWithCause(status.FromProto(s), cerr)
+}
@@ -0,0 +1,98 @@
+package connecterr_test
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/connecterr"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func ExampleToConnect() {
+	err := errors.NewO("user not found", errors.NotFound, errors.WithTraceContext(errors.TraceContext{
+		TraceID:    "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:     "00f067aa0ba902b7",
+		TraceFlags: 1,
+	}))
+	err = errors.WithPublicMessage(err, "user not found")
+
+	cerr := connecterr.ToConnect(err)
+	fmt.Println(cerr.Code(), cerr.Message())
+	fmt.Println(cerr.Meta().Get(connecterr.MetaTraceParent))
+
+	back := connecterr.FromConnect(cerr)
+	e := back.(errors.ErrorTracer)
+	fmt.Println(back, errors.CodeOf(back))
+	fmt.Println(e.TraceContext().SpanID)
+	fmt.Println(errors.IDOf(e) == errors.IDOf(err))
+	var target *connect.Error
+	fmt.Println(errors.As(back, &target))
+
+	// Output:
+	// not_found user not found
+	// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+	// user not found NOT_FOUND
+	// 00f067aa0ba902b7
+	// true
+	// true
+}
+
+func ExampleInterceptor() {
+	handler := connecterr.Interceptor()(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		return nil, errors.WithCode(errors.New("invalid user ID"), errors.InvalidArgument)
+	})
+
+	_, err := handler(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	fmt.Println(connect.CodeOf(err), err)
+
+	// Output:
+	// invalid_argument invalid_argument: Bad Request
+}
+
+func ExampleInterceptor_connectError() {
+	handler := connecterr.Interceptor()(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		cerr := connect.NewError(connect.CodeNotFound, fmt.Errorf("user 42 not found"))
+		cerr.Meta().Set("User", "42")
+		return nil, cerr
+	})
+
+	_, err := handler(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	var cerr *connect.Error
+	errors.As(err, &cerr)
+	fmt.Println(cerr.Code(), cerr.Message(), cerr.Meta().Get("User"))
+
+	// Output:
+	// not_found user 42 not found 42
+}
+
+func ExampleToConnect_wrapped() {
+	cerr := connect.NewError(connect.CodeNotFound, fmt.Errorf("user 42 not found"))
+	if detail, err := connect.NewErrorDetail(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "id", Description: "unknown user"}},
+	}); err == nil {
+		cerr.AddDetail(detail)
+	}
+
+	err := connecterr.ToConnect(fmt.Errorf("wrapped: %w", cerr))
+	fmt.Println(err.Code(), err.Message())
+	for _, d := range err.Details() {
+		fmt.Println(d.Type())
+	}
+
+	// The details are not repeated when the error of a call is returned to the caller.
+	err = connecterr.ToConnect(errors.Wrap(connecterr.FromConnect(cerr), "get user"))
+	fmt.Println(err.Code(), err.Message())
+	for _, d := range err.Details() {
+		fmt.Println(d.Type())
+	}
+
+	// Output:
+	// not_found user 42 not found
+	// google.rpc.BadRequest
+	// not_found user 42 not found
+	// google.rpc.BadRequest
+	// google.rpc.ErrorInfo
+}
@@ -0,0 +1,47 @@
+package errors
+
+import (
+	"net/url"
+	"strings"
+)
+
+const cloudConsoleURL = "https://console.cloud.google.com"
+
+// TraceURL returns the link to the trace of err, to jump from an alert straight to the trace.
+// It is a link to the Jaeger UI when Options.JaegerURL is set, and otherwise to the Google Cloud Console
+// trace explorer of Options.ProjectID.
+// The trace context is the outermost one in the chain of err.
+// It returns an empty string when err has no trace context, or neither option is set.
+func TraceURL(err error) string {
+	tc := traceContextOf(err)
+	if tc.TraceID == "" {
+		return ""
+	}
+	o := config()
+	if o.JaegerURL != "" {
+		u := strings.TrimSuffix(o.JaegerURL, "/") + "/trace/" + tc.TraceID
+		if tc.SpanID != "" {
+			u += "?uiFind=" + tc.SpanID
+		}
+		return u
+	}
+	if o.ProjectID == "" {
+		return ""
+	}
+	q := url.Values{"project": {o.ProjectID}, "tid": {tc.TraceID}}
+	return cloudConsoleURL + "/traces/list?" + q.Encode()
+}
+
+// LogsURL returns the link to the logs of the trace of err in the Google Cloud Console logs explorer
+// of Options.ProjectID.
+// The trace context is the outermost one in the chain of err.
+// It returns an empty string when err has no trace context, or no project ID is set.
+func LogsURL(err error) string {
+	tc := traceContextOf(err)
+	id := config().ProjectID
+	if tc.TraceID == "" || id == "" {
+		return ""
+	}
+	query := `trace="` + traceName(tc.TraceID) + `"`
+	return cloudConsoleURL + "/logs/query;query=" + url.PathEscape(query) + "?" + url.Values{"project": {id}}.Encode()
+}
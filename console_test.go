@@ -0,0 +1,28 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleTraceURL() {
+	errors.Configure(errors.Options{ProjectID: "my-project"})
+	defer errors.Configure(errors.Options{})
+
+	err := errors.NewO("user not found", errors.WithTraceContext(errors.TraceContext{
+		TraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:  "00f067aa0ba902b7",
+	}))
+	err = errors.Wrap(err, "get user")
+	fmt.Println(errors.TraceURL(err))
+	fmt.Println(errors.LogsURL(err))
+
+	errors.Configure(errors.Options{JaegerURL: "http://localhost:16686/"})
+	fmt.Println(errors.TraceURL(err))
+
+	// Output:
+	// https://console.cloud.google.com/traces/list?project=my-project&tid=4bf92f3577b34da6a3ce929d0e0e4736
+	// https://console.cloud.google.com/logs/query;query=trace=%22projects%2Fmy-project%2Ftraces%2F4bf92f3577b34da6a3ce929d0e0e4736%22?project=my-project
+	// http://localhost:16686/trace/4bf92f3577b34da6a3ce929d0e0e4736?uiFind=00f067aa0ba902b7
+}
@@ -0,0 +1,32 @@
+package errors
+
+import "strconv"
+
+// Datadog log correlation attributes.
+// See https://docs.datadoghq.com/tracing/other_telemetry/connect_logs_and_traces/.
+const (
+	logKeyDatadogTraceID = "dd.trace_id"
+	logKeyDatadogSpanID  = "dd.span_id"
+)
+
+// DatadogFields returns the dd.trace_id and dd.span_id key value pairs of the trace context,
+// as the unsigned decimal numbers Datadog uses to correlate logs and traces.
+// The trace ID is the lower 64 bits of the 128-bit trace ID.
+// It returns nil when there is no trace context or the IDs are not hexadecimal.
+func (t TraceContext) DatadogFields() []interface{} {
+	if len(t.TraceID) < 16 || t.SpanID == "" {
+		return nil
+	}
+	traceID, err := strconv.ParseUint(t.TraceID[len(t.TraceID)-16:], 16, 64)
+	if err != nil {
+		return nil
+	}
+	spanID, err := strconv.ParseUint(t.SpanID, 16, 64)
+	if err != nil {
+		return nil
+	}
+	return []interface{}{
+		logKeyDatadogTraceID, strconv.FormatUint(traceID, 10),
+		logKeyDatadogSpanID, strconv.FormatUint(spanID, 10),
+	}
+}
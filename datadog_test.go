@@ -0,0 +1,33 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleTraceContext_DatadogFields() {
+	tc := errors.TraceContext{
+		TraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:  "00f067aa0ba902b7",
+	}
+	fmt.Println(tc.DatadogFields())
+
+	// Output:
+	// [dd.trace_id 11803532876627986230 dd.span_id 67667974448284343]
+}
+
+func ExampleOptions_datadogFields() {
+	errors.Configure(errors.Options{DatadogFields: true})
+	defer errors.Configure(errors.Options{})
+
+	err := errors.New("a")
+	if perr := errors.SetTraceContextFromTraceParent(err, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"); perr != nil {
+		fmt.Println(perr)
+	}
+	fields := errors.LogFieldsMap(err)
+	fmt.Println(fields["dd.trace_id"], fields["dd.span_id"])
+
+	// Output:
+	// 11803532876627986230 67667974448284343
+}
@@ -0,0 +1,116 @@
+package errors
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// KeyOccurrences is the field of the number of occurrences of an error passed by a Deduper,
+// including the repeated errors it suppressed before it.
+const KeyOccurrences = "occurrences"
+
+// Deduper suppresses the repeated errors of the same fingerprint within a time window, see Fingerprint.
+// It is meant to prevent log storms during outages, when wrapping the hooks and the Reporter.
+//
+//	d := errors.NewDeduper(time.Minute)
+//	errors.AddHook(d.Hook(logError))
+//	errors.SetReporter(d.Reporter(errsentry.NewReporter(nil)), errors.ReporterOptions{})
+//
+// A Deduper is safe for concurrent use.
+type Deduper struct {
+	window time.Duration
+
+	mu        sync.Mutex
+	seen      map[string]*dedupeEntry
+	lastSweep time.Time
+}
+
+// dedupeEntry is the window of a fingerprint.
+type dedupeEntry struct {
+	start      time.Time
+	suppressed int
+}
+
+// NewDeduper returns a Deduper that passes one error of each fingerprint per window.
+func NewDeduper(window time.Duration) *Deduper {
+	return &Deduper{
+		window: window,
+		seen:   map[string]*dedupeEntry{},
+	}
+}
+
+// Dedupe returns nil when an error of the fingerprint of err was passed less than a window ago.
+// Otherwise, it returns a copy of err with the KeyOccurrences field, which counts err and the errors
+// suppressed since the last error of its fingerprint was passed.
+// The suppressed errors of a fingerprint that does not occur again after its window are not counted.
+// It returns nil if err is nil.
+func (d *Deduper) Dedupe(err error) error {
+	if err == nil {
+		return nil
+	}
+	fp := Fingerprint(err)
+	now := time.Now()
+
+	d.mu.Lock()
+	entry, ok := d.seen[fp]
+	if ok && now.Sub(entry.start) < d.window {
+		entry.suppressed++
+		d.mu.Unlock()
+		return nil
+	}
+	occurrences := 1
+	if ok {
+		occurrences += entry.suppressed
+	}
+	d.seen[fp] = &dedupeEntry{start: now}
+	d.sweep(now)
+	d.mu.Unlock()
+
+	e, ok := err.(*errorContext)
+	if ok {
+		e = e.clone()
+	} else {
+		e = newErrorContext(err, location{})
+	}
+	e.fields = e.fields.merge(Fields{KeyOccurrences: occurrences})
+	return e
+}
+
+// sweep forgets the fingerprints whose window is over, at most once per window.
+func (d *Deduper) sweep(now time.Time) {
+	if now.Sub(d.lastSweep) < d.window {
+		return
+	}
+	d.lastSweep = now
+	for fp, entry := range d.seen {
+		if now.Sub(entry.start) >= d.window {
+			delete(d.seen, fp)
+		}
+	}
+}
+
+// Hook returns a Hook that calls h with the errors passed by d, see Dedupe.
+func (d *Deduper) Hook(h Hook) Hook {
+	return func(e ErrorTracer) {
+		if err := d.Dedupe(e); err != nil {
+			h(err.(ErrorTracer))
+		}
+	}
+}
+
+// Reporter returns a Reporter that reports the errors passed by d with r, see Dedupe.
+func (d *Deduper) Reporter(r Reporter) Reporter {
+	return ReporterFunc(func(ctx context.Context, errs []error) error {
+		var passed []error
+		for _, err := range errs {
+			if err := d.Dedupe(err); err != nil {
+				passed = append(passed, err)
+			}
+		}
+		if len(passed) == 0 {
+			return nil
+		}
+		return r.Report(ctx, passed)
+	})
+}
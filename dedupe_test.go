@@ -0,0 +1,30 @@
+package errors_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleDeduper() {
+	d := errors.NewDeduper(50 * time.Millisecond)
+	for i := 0; i < 4; i++ {
+		if i == 3 {
+			// The window is over, the next error counts the suppressed ones.
+			time.Sleep(60 * time.Millisecond)
+		}
+		err := d.Dedupe(errors.New("connection refused"))
+		if err == nil {
+			fmt.Println("suppressed")
+			continue
+		}
+		fmt.Println(err, errors.FieldsOf(err)[errors.KeyOccurrences])
+	}
+
+	// Output:
+	// connection refused 1
+	// suppressed
+	// suppressed
+	// connection refused 3
+}
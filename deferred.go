@@ -0,0 +1,29 @@
+package errors
+
+import "sync"
+
+// pendingAnnotation is the deferred annotation of an error, see Options.DeferAnnotation.
+type pendingAnnotation struct {
+	once      sync.Once
+	annotator SpanAnnotator
+}
+
+// AnnotateDeferred annotates the errors of the chain of err whose annotation was deferred by
+// Options.DeferAnnotation on their span.
+// Every error is annotated once, however many times it is logged.
+// The functions that log, report or serialize the errors call it, so that only the errors
+// that are logged, reported or returned are annotated: LogFields and the functions built on it
+// (SlogAttrs, LogFieldsMap and the logger adapters), the slog LogValue of the errors, ECSFields,
+// ToReportedErrorEvent, Report, ToProto and the functions built on it (Marshal, MarshalProto),
+// ToProblem, the %+v formatting of the errors, and the conversions of the adapters, e.g. grpcerr.ToStatus.
+// The other functions, e.g. Error, FieldsOf or Tree, do not; call it when such an error is surfaced.
+func AnnotateDeferred(err error) {
+	visit(err, func(e *errorContext) bool {
+		if p := e.pending; p != nil {
+			p.once.Do(func() {
+				annotateSpan(e, p.annotator)
+			})
+		}
+		return false
+	})
+}
@@ -0,0 +1,61 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bzon/errors"
+	"go.opencensus.io/trace"
+)
+
+func ExampleAnnotateDeferred() {
+	errors.Configure(errors.Options{DeferAnnotation: true})
+	defer errors.Configure(errors.Options{})
+
+	r := &spanRecorder{}
+	trace.RegisterExporter(r)
+	defer trace.UnregisterExporter(r)
+
+	_, span := trace.StartSpan(context.Background(), "get", trace.WithSampler(trace.AlwaysSample()))
+	// A cache miss that is handled is not annotated.
+	_ = errors.NewT(span, "cache miss")
+	err := errors.WithCode(errors.NewT(span, "user not found"), errors.NotFound)
+	// Logging the error, or a copy of it, twice annotates it once.
+	_ = errors.LogFields(err)
+	_ = errors.LogFields(errors.Wrap(err, "get user"))
+	span.End()
+
+	for _, a := range r.spans[0].Annotations {
+		fmt.Println(a.Message)
+	}
+	fmt.Println(r.spans[0].Status.Code)
+
+	// Output:
+	// Error: user not found
+	// 5
+}
+
+func ExampleAnnotateDeferred_serialized() {
+	errors.Configure(errors.Options{DeferAnnotation: true})
+	defer errors.Configure(errors.Options{})
+
+	r := &spanRecorder{}
+	trace.RegisterExporter(r)
+	defer trace.UnregisterExporter(r)
+
+	_, span := trace.StartSpan(context.Background(), "get", trace.WithSampler(trace.AlwaysSample()))
+	// The errors returned to clients or sent to another process are annotated too.
+	_ = errors.ToProblem(errors.NewT(span, "user not found"))
+	_ = errors.Marshal(errors.NewT(span, "quota exceeded"))
+	_ = fmt.Sprintf("%+v", errors.NewT(span, "timeout"))
+	span.End()
+
+	for _, a := range r.spans[0].Annotations {
+		fmt.Println(a.Message)
+	}
+
+	// Output:
+	// Error: user not found
+	// Error: quota exceeded
+	// Error: timeout
+}
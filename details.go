@@ -0,0 +1,77 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// WithDetail returns a copy of err that carries the detail v, a structured payload that describes the error
+// for machines, like the details of google.rpc.Status, e.g. a *errdetails.QuotaFailure or an *errdetails.BadRequest.
+// Marshal and MarshalProto keep the details, protocol buffers messages as such, and other values as JSON values.
+// If err is not created by this package, it is wrapped with the source location of the caller.
+// It returns nil if err is nil.
+func WithDetail(err error, v interface{}) error {
+	if err == nil {
+		return nil
+	}
+	e := withContext(err, wrappedFunctionCallDepth)
+	e.details = append(e.details[:len(e.details):len(e.details)], v)
+	return e
+}
+
+// Details returns the details of every error in the chain of err, outermost first, see WithDetail.
+// It returns nil when there are none.
+func Details(err error) []interface{} {
+	var details []interface{}
+	visit(err, func(e *errorContext) bool {
+		details = append(details, e.details...)
+		return false
+	})
+	return details
+}
+
+// detailToProto encodes the detail v as a JSON value.
+// Protocol buffers messages are encoded like google.protobuf.Any, with their @type, and other values with encoding/json.
+func detailToProto(v interface{}) *structpb.Value {
+	if m, ok := v.(proto.Message); ok {
+		if a, err := anypb.New(m); err == nil {
+			if b, err := protojson.Marshal(a); err == nil {
+				value := &structpb.Value{}
+				if protojson.Unmarshal(b, value) == nil {
+					return value
+				}
+			}
+		}
+	}
+	if value, err := structpb.NewValue(v); err == nil {
+		return value
+	}
+	var x interface{}
+	if b, err := json.Marshal(v); err == nil && json.Unmarshal(b, &x) == nil {
+		if value, err := structpb.NewValue(x); err == nil {
+			return value
+		}
+	}
+	return structpb.NewStringValue(fmt.Sprint(v))
+}
+
+// detailFromProto decodes a detail encoded by detailToProto.
+// The protocol buffers messages whose type is not linked in the binary are decoded as maps, like the other values.
+func detailFromProto(value *structpb.Value) interface{} {
+	if s := value.GetStructValue(); s != nil && s.Fields["@type"] != nil {
+		if b, err := protojson.Marshal(value); err == nil {
+			a := &anypb.Any{}
+			if protojson.Unmarshal(b, a) == nil {
+				if m, err := a.UnmarshalNew(); err == nil {
+					return m
+				}
+			}
+		}
+	}
+	return value.AsInterface()
+}
@@ -0,0 +1,30 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func ExampleWithDetail() {
+	err := errors.NewO("too many requests", errors.ResourceExhausted)
+	err = errors.WithDetail(err, &errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{{Subject: "user:1", Description: "100 requests per minute"}},
+	})
+	err = errors.WithDetail(err, map[string]interface{}{"retryAfter": 30})
+
+	// The details cross process boundaries.
+	for _, d := range errors.Details(errors.Unmarshal(errors.Marshal(err))) {
+		switch d := d.(type) {
+		case *errdetails.QuotaFailure:
+			fmt.Println(d.Violations[0].Subject, d.Violations[0].Description)
+		default:
+			fmt.Println(d)
+		}
+	}
+
+	// Output:
+	// user:1 100 requests per minute
+	// map[retryAfter:30]
+}
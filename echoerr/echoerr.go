@@ -0,0 +1,36 @@
+// Package echoerr handles the errors of Echo handlers.
+// The errors are annotated on the span of the request, logged with the Stackdriver fields,
+// and written as application/problem+json responses with the HTTP status of the error.
+//
+//	e := echo.New()
+//	e.HTTPErrorHandler = echoerr.HTTPErrorHandler
+package echoerr
+
+import (
+	"log/slog"
+
+	"github.com/bzon/errors"
+	"github.com/labstack/echo/v4"
+)
+
+// HTTPErrorHandler is an echo.HTTPErrorHandler.
+// The error is wrapped with the request method and path and annotated on the span of the request.
+// It keeps the source location where it occurred, see errors.AtOrigin.
+// The status of an *echo.HTTPError is kept, e.g. 404 for unknown routes.
+// It is logged with the Stackdriver fields, and the httpRequest of the request, to the slog default logger,
+// and written as an application/problem+json response, see errors.ToProblem,
+// unless the handler has written a response already.
+func HTTPErrorHandler(err error, c echo.Context) {
+	r := c.Request()
+	ctx := r.Context()
+	if he, ok := errors.AsType[*echo.HTTPError](err); ok && errors.CodeOf(err) == errors.Unknown {
+		err = errors.WithHTTPStatus(err, he.Code)
+	}
+	err = errors.WrapO(err, r.Method+" "+r.URL.Path, errors.WithContext(ctx), errors.AtOrigin())
+	err = errors.WithHTTPRequest(err, r)
+	slog.Default().LogAttrs(ctx, slog.LevelError, errors.RedactedMessage(err), errors.SlogAttrs(err)...)
+	if c.Response().Committed {
+		return
+	}
+	errors.WriteProblem(c.Response(), err)
+}
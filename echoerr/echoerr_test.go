@@ -0,0 +1,36 @@
+package echoerr_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/echoerr"
+	"github.com/labstack/echo/v4"
+)
+
+func ExampleHTTPErrorHandler() {
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	e := echo.New()
+	e.HTTPErrorHandler = echoerr.HTTPErrorHandler
+	e.GET("/users/:id", func(c echo.Context) error {
+		return errors.WithCode(errors.New("user not found"), errors.NotFound)
+	})
+
+	for _, path := range []string{"/users/1", "/unknown"} {
+		w := httptest.NewRecorder()
+		e.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		p := errors.ProblemDetails{}
+		_ = json.Unmarshal(w.Body.Bytes(), &p)
+		fmt.Println(w.Code, w.Header().Get("Content-Type"), p.Detail, p.Instance)
+	}
+
+	// Output:
+	// 404 application/problem+json Not Found /users/1
+	// 404 application/problem+json Not Found /unknown
+}
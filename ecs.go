@@ -0,0 +1,75 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Elastic Common Schema fields.
+// See https://www.elastic.co/guide/en/ecs/current/ecs-field-reference.html.
+const (
+	ecsKeyErrorMessage    = "error.message"
+	ecsKeyErrorType       = "error.type"
+	ecsKeyErrorCode       = "error.code"
+	ecsKeyErrorID         = "error.id"
+	ecsKeyErrorStackTrace = "error.stack_trace"
+	ecsKeyTraceID         = "trace.id"
+	ecsKeySpanID          = "span.id"
+	ecsKeyLogLevel        = "log.level"
+	ecsKeyOriginFile      = "log.origin.file.name"
+	ecsKeyOriginLine      = "log.origin.file.line"
+	ecsKeyOriginFunction  = "log.origin.function"
+	ecsKeyServiceName     = "service.name"
+	ecsKeyServiceVersion  = "service.version"
+	ecsKeyEnvironment     = "service.environment"
+)
+
+// ECSFields returns the Elastic Common Schema key value pairs of err, for logs shipped to Elasticsearch:
+// its scrubbed message, the type of its cause, its code and ID, its stack trace if any,
+// the trace context and source location of the outermost ErrorTracer in the chain of err,
+// and its severity as log level.
+// The service fields are added from the options set by Configure.
+// It returns nil for a nil error.
+//
+//	logger.Log(append([]interface{}{"message", "failed"}, errors.ECSFields(err)...)...)
+func ECSFields(err error) []interface{} {
+	if err == nil {
+		return nil
+	}
+	AnnotateDeferred(err)
+	fields := []interface{}{
+		ecsKeyErrorMessage, RedactedMessage(err),
+		ecsKeyErrorType, fmt.Sprintf("%T", Cause(err)),
+		ecsKeyErrorCode, CodeOf(err).String(),
+		ecsKeyLogLevel, strings.ToLower(SeverityOf(err).String()),
+	}
+	if stack := stackTrace(err); stack != "" {
+		fields = append(fields, ecsKeyErrorStackTrace, stack)
+	}
+	if e, ok := TracerOf(err); ok {
+		src := e.SourceLocation()
+		fields = append(fields,
+			ecsKeyErrorID, IDOf(e),
+			ecsKeyOriginFile, src.File,
+			ecsKeyOriginLine, src.Line,
+			ecsKeyOriginFunction, src.Function,
+		)
+	}
+	if tc := traceContextOf(err); tc.TraceID != "" {
+		fields = append(fields,
+			ecsKeyTraceID, tc.TraceID,
+			ecsKeySpanID, tc.SpanID,
+		)
+	}
+	o := config()
+	if o.ServiceName != "" {
+		fields = append(fields,
+			ecsKeyServiceName, o.ServiceName,
+			ecsKeyServiceVersion, VERSION,
+		)
+	}
+	if o.Environment != "" {
+		fields = append(fields, ecsKeyEnvironment, o.Environment)
+	}
+	return fields
+}
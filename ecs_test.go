@@ -0,0 +1,31 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleECSFields() {
+	err := errors.NewO("user not found", errors.NotFound, errors.WithTraceContext(errors.TraceContext{
+		TraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:  "00f067aa0ba902b7",
+	}))
+	fields := errors.ECSFields(errors.Wrap(err, "get user"))
+	for i := 0; i < len(fields); i += 2 {
+		switch fields[i] {
+		case "error.id", "log.origin.file.name", "log.origin.file.line":
+			continue
+		}
+		fmt.Println(fields[i], fields[i+1])
+	}
+
+	// Output:
+	// error.message get user: user not found
+	// error.type *errors.errorString
+	// error.code NOT_FOUND
+	// log.level error
+	// log.origin.function github.com/bzon/errors_test.ExampleECSFields
+	// trace.id 4bf92f3577b34da6a3ce929d0e0e4736
+	// span.id 00f067aa0ba902b7
+}
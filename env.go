@@ -0,0 +1,38 @@
+package errors
+
+import "os"
+
+// environmentVariables maps the fields of EnvironmentFields to the environment variables they are read from.
+// The keys are the OpenTelemetry resource semantic conventions.
+var environmentVariables = map[string]string{
+	// Cloud Run services and Knative, see https://cloud.google.com/run/docs/container-contract#env-vars.
+	"faas.name":    "K_SERVICE",
+	"faas.version": "K_REVISION",
+	// Cloud Run jobs.
+	"gcp.cloud_run.job.execution":  "CLOUD_RUN_EXECUTION",
+	"gcp.cloud_run.job.task_index": "CLOUD_RUN_TASK_INDEX",
+	// Kubernetes, usually set with the downward API,
+	// see https://kubernetes.io/docs/tasks/inject-data-application/environment-variable-expose-pod-information.
+	"k8s.pod.name":       "POD_NAME",
+	"k8s.pod.uid":        "POD_UID",
+	"k8s.namespace.name": "POD_NAMESPACE",
+	"k8s.node.name":      "NODE_NAME",
+}
+
+// EnvironmentFields returns the fields that identify the workload instance, read from the standard environment
+// variables of Cloud Run and of the Kubernetes downward API, e.g. k8s.pod.name from POD_NAME.
+// The variables that are not set are omitted. It returns nil when none is set.
+func EnvironmentFields() Fields {
+	var fields Fields
+	for k, env := range environmentVariables {
+		v := os.Getenv(env)
+		if v == "" {
+			continue
+		}
+		if fields == nil {
+			fields = make(Fields, len(environmentVariables))
+		}
+		fields[k] = v
+	}
+	return fields
+}
@@ -0,0 +1,41 @@
+package errors_test
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleEnvironmentFields() {
+	os.Setenv("POD_NAME", "user-service-7d9f8b-x2x4q")
+	os.Setenv("NODE_NAME", "gke-pool-1-abcd")
+	defer os.Unsetenv("POD_NAME")
+	defer os.Unsetenv("NODE_NAME")
+
+	fmt.Println(errors.EnvironmentFields())
+
+	// Output:
+	// map[k8s.node.name:gke-pool-1-abcd k8s.pod.name:user-service-7d9f8b-x2x4q]
+}
+
+func ExampleOptions_environmentFields() {
+	os.Setenv("K_SERVICE", "user-service")
+	os.Setenv("K_REVISION", "user-service-00042-kaz")
+	defer os.Unsetenv("K_SERVICE")
+	defer os.Unsetenv("K_REVISION")
+
+	errors.Configure(errors.Options{
+		EnvironmentFields: true,
+		DefaultFields:     errors.Fields{"region": "europe-west1"},
+	})
+	defer errors.Configure(errors.Options{})
+
+	err := errors.NewO("a", errors.WithFields(errors.Fields{"region": "us-east1"}))
+	fmt.Println(errors.FieldsOf(err))
+	fmt.Println(errors.FieldsOf(fmt.Errorf("untraced")))
+
+	// Output:
+	// map[faas.name:user-service faas.version:user-service-00042-kaz region:us-east1]
+	// map[]
+}
@@ -0,0 +1,34 @@
+package errors
+
+import "reflect"
+
+// Is reports whether target is a copy of e, e.g. made by WithCode, or e a copy of target.
+// The copies share the error they wrap, whatever their source locations, trace contexts and other metadata,
+// so that sentinel errors of this package still match with errors.Is once annotated:
+//
+//	var ErrNotFound = errors.New("not found")
+//
+//	errors.Is(errors.WithCode(ErrNotFound, errors.NotFound), ErrNotFound) // true
+func (e *errorContext) Is(target error) bool {
+	t, ok := target.(*errorContext)
+	if !ok || t.err == nil || !reflect.TypeOf(t.err).Comparable() {
+		return false
+	}
+	return e.err == t.err
+}
+
+// Equivalent reports whether a and b are the same error, regardless of the metadata of this package,
+// e.g. their source locations, trace contexts and IDs.
+// They are equivalent when one matches the other with errors.Is,
+// or when they have the same message and code and causes of the same type, see Cause.
+func Equivalent(a, b error) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if Is(a, b) || Is(b, a) {
+		return true
+	}
+	return a.Error() == b.Error() &&
+		CodeOf(a) == CodeOf(b) &&
+		reflect.TypeOf(Cause(a)) == reflect.TypeOf(Cause(b))
+}
@@ -0,0 +1,25 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+)
+
+var errNotFound = errors.New("not found")
+
+func ExampleEquivalent() {
+	a := errors.WithCode(errNotFound, errors.NotFound)
+	b := errors.New("not found")
+
+	fmt.Println(errors.Is(a, errNotFound))
+	fmt.Println(errors.Equivalent(a, errNotFound))
+	fmt.Println(errors.Equivalent(errors.WithCode(b, errors.NotFound), a))
+	fmt.Println(errors.Equivalent(b, a))
+
+	// Output:
+	// true
+	// true
+	// true
+	// false
+}
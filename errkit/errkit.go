@@ -0,0 +1,23 @@
+// Package errkit logs errors with github.com/go-kit/kit/log.
+package errkit
+
+import (
+	"github.com/bzon/errors"
+)
+
+// Keyvals returns the key value pairs to log err with a go-kit logger:
+// the message of err, errors.LogFields of err and the fields of err.
+// The message and the fields are scrubbed by the redactors, see errors.AddRedactor.
+// It returns nil for a nil error.
+//
+//	logger.Log(errkit.Keyvals(err)...)
+func Keyvals(err error) []interface{} {
+	if err == nil {
+		return nil
+	}
+	keyvals := append([]interface{}{"message", errors.RedactedMessage(err)}, errors.LogFields(err)...)
+	if fields := errors.RedactedFields(err); len(fields) > 0 {
+		keyvals = append(keyvals, "fields", fields)
+	}
+	return keyvals
+}
@@ -0,0 +1,26 @@
+package errkit_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/errkit"
+)
+
+func ExampleKeyvals() {
+	err := errors.NewO("user not found", errors.WithFields(errors.Fields{"user": 1}))
+	keyvals := errkit.Keyvals(err)
+	for i := 0; i < len(keyvals); i += 2 {
+		// Skip the fields that depend on the file path or the occurrence.
+		switch keyvals[i] {
+		case "logging.googleapis.com/sourceLocation", "fingerprint", "errorId":
+			continue
+		}
+		fmt.Println(keyvals[i], keyvals[i+1])
+	}
+
+	// Output:
+	// message user not found
+	// severity ERROR
+	// fields map[user:1]
+}
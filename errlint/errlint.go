@@ -0,0 +1,151 @@
+// Package errlint defines an analyzer that checks the adoption of github.com/bzon/errors.
+//
+// It reports, in the packages that import github.com/bzon/errors:
+//   - Caller constructors, e.g. NewCaller and WrapCaller, with a depth that points inside
+//     github.com/bzon/errors or beyond main.
+//   - Calls to fmt.Errorf, that create errors without source location and trace context.
+//   - Errors returned as is after an err != nil check, without wrapping.
+//
+// The analyzer is run by cmd/errlint, on its own or with go vet:
+//
+//	go vet -vettool=$(which errlint) ./...
+package errlint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const errorsPath = "github.com/bzon/errors"
+
+// callerDepth is the depth of the Caller constructors that points to the function calling them.
+const callerDepth = 2
+
+// Analyzer checks the adoption of github.com/bzon/errors.
+var Analyzer = &analysis.Analyzer{
+	Name:     "errlint",
+	Doc:      "check the caller depths, fmt.Errorf calls and unwrapped errors in packages that use github.com/bzon/errors",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	if !imports(pass.Pkg, errorsPath) {
+		return nil, nil
+	}
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodes := []ast.Node{(*ast.CallExpr)(nil), (*ast.IfStmt)(nil)}
+	ins.WithStack(nodes, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			checkCall(pass, n, stack)
+		case *ast.IfStmt:
+			checkUnwrapped(pass, n)
+		}
+		return true
+	})
+	return nil, nil
+}
+
+func imports(pkg *types.Package, path string) bool {
+	for _, imp := range pkg.Imports() {
+		if imp.Path() == path {
+			return true
+		}
+	}
+	return false
+}
+
+// callee returns the package path and name of the function called by call, if it is a package-level function.
+func callee(pass *analysis.Pass, call *ast.CallExpr) (pkg, name string) {
+	var id *ast.Ident
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	default:
+		return "", ""
+	}
+	fn, ok := pass.TypesInfo.Uses[id].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Type().(*types.Signature).Recv() != nil {
+		return "", ""
+	}
+	return fn.Pkg().Path(), fn.Name()
+}
+
+func checkCall(pass *analysis.Pass, call *ast.CallExpr, stack []ast.Node) {
+	pkg, name := callee(pass, call)
+	switch {
+	case pkg == "fmt" && name == "Errorf":
+		pass.Reportf(call.Pos(), "fmt.Errorf creates an error without source location, use errors.Errorf or errors.Wrapf")
+	case pkg == errorsPath && strings.Contains(name, "Caller") && len(call.Args) > 0:
+		tv, ok := pass.TypesInfo.Types[call.Args[0]]
+		if !ok || tv.Value == nil || tv.Value.Kind() != constant.Int {
+			return
+		}
+		depth, _ := constant.Int64Val(tv.Value)
+		switch {
+		case depth < callerDepth:
+			pass.Reportf(call.Args[0].Pos(), "%s depth %d points inside %s, use %d for the calling function", name, depth, errorsPath, callerDepth)
+		case depth > callerDepth && inMain(pass, stack):
+			pass.Reportf(call.Args[0].Pos(), "%s depth %d points beyond main, use %d", name, depth, callerDepth)
+		}
+	}
+}
+
+// inMain reports whether the innermost function of stack is main.main.
+func inMain(pass *analysis.Pass, stack []ast.Node) bool {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.FuncDecl:
+			return pass.Pkg.Name() == "main" && fn.Recv == nil && fn.Name.Name == "main"
+		}
+	}
+	return false
+}
+
+// checkUnwrapped reports the errors returned as is in the body of an if err != nil statement.
+func checkUnwrapped(pass *analysis.Pass, stmt *ast.IfStmt) {
+	cond, ok := stmt.Cond.(*ast.BinaryExpr)
+	if !ok || cond.Op != token.NEQ || !isNil(pass, cond.Y) {
+		return
+	}
+	id, ok := cond.X.(*ast.Ident)
+	if !ok || !isError(pass.TypesInfo.TypeOf(id)) {
+		return
+	}
+	obj := pass.TypesInfo.Uses[id]
+	for _, s := range stmt.Body.List {
+		ret, ok := s.(*ast.ReturnStmt)
+		if !ok {
+			continue
+		}
+		for _, r := range ret.Results {
+			if rid, ok := r.(*ast.Ident); ok && obj != nil && pass.TypesInfo.Uses[rid] == obj {
+				pass.Reportf(r.Pos(), "%s is returned without wrapping, use errors.Wrap", rid.Name)
+			}
+		}
+	}
+}
+
+func isNil(pass *analysis.Pass, e ast.Expr) bool {
+	tv, ok := pass.TypesInfo.Types[e]
+	return ok && tv.IsNil()
+}
+
+func isError(t types.Type) bool {
+	return t != nil && types.Identical(t, types.Universe.Lookup("error").Type())
+}
@@ -0,0 +1,14 @@
+package errlint_test
+
+import (
+	"testing"
+
+	"github.com/bzon/errors/errlint"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// TestAnalyzer runs the analyzer on the packages of testdata/src and compares its diagnostics
+// with their // want comments.
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), errlint.Analyzer, "a", "b", "c")
+}
@@ -0,0 +1,37 @@
+package a
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bzon/errors"
+)
+
+func open(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err // want `err is returned without wrapping, use errors.Wrap`
+	}
+	return f.Close()
+}
+
+func wrapped(name string) error {
+	_, err := os.Open(name)
+	if err != nil {
+		return errors.Wrap(err, "open")
+	}
+	return nil
+}
+
+func formatted(name string) error {
+	return fmt.Errorf("open %s", name) // want `fmt.Errorf creates an error without source location`
+}
+
+func notFound() error {
+	return errors.NewCaller(3, "not found")
+}
+
+func depths() error {
+	_ = errors.NewCaller(1, "a") // want `NewCaller depth 1 points inside github.com/bzon/errors`
+	return errors.NewCaller(2, "a")
+}
@@ -0,0 +1,8 @@
+// Package b does not use github.com/bzon/errors.
+package b
+
+import "fmt"
+
+func formatted(name string) error {
+	return fmt.Errorf("open %s", name)
+}
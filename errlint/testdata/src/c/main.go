@@ -0,0 +1,10 @@
+package main
+
+import "github.com/bzon/errors"
+
+func main() {
+	_ = errors.WrapCaller(3, nil, "a") // want `WrapCaller depth 3 points beyond main`
+	func() {
+		_ = errors.NewCaller(3, "a")
+	}()
+}
@@ -0,0 +1,7 @@
+// Package errors is a stub of github.com/bzon/errors.
+package errors
+
+func New(m string) error                            { return nil }
+func Wrap(e error, m string) error                  { return e }
+func NewCaller(depth int, m string) error           { return nil }
+func WrapCaller(depth int, e error, m string) error { return e }
@@ -0,0 +1,49 @@
+// Package errlogrus logs errors with github.com/sirupsen/logrus.
+// The errors are logged with the Stackdriver fields, like errors.LogFields does for go-kit loggers.
+package errlogrus
+
+import (
+	"github.com/bzon/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Fields returns errors.LogFields of err as logrus fields.
+// It returns nil when err is not an errors.ErrorTracer.
+//
+//	logger.WithFields(errlogrus.Fields(err)).Error(err)
+func Fields(err error) logrus.Fields {
+	fields := errors.LogFieldsMap(err)
+	if fields == nil {
+		return nil
+	}
+	return logrus.Fields(fields)
+}
+
+// Hook is a logrus hook that adds Fields of the error of an entry, set by WithError, to the entry.
+// The fields that are already set on the entry are kept.
+//
+//	logger.AddHook(errlogrus.Hook{})
+//	logger.WithError(err).Error("failed")
+type Hook struct{}
+
+// Compile time implementation check.
+var _ logrus.Hook = Hook{}
+
+// Levels implements logrus.Hook. The hook fires for all levels.
+func (Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook.
+func (Hook) Fire(entry *logrus.Entry) error {
+	err, ok := entry.Data[logrus.ErrorKey].(error)
+	if !ok {
+		return nil
+	}
+	for k, v := range Fields(err) {
+		if _, ok := entry.Data[k]; !ok {
+			entry.Data[k] = v
+		}
+	}
+	return nil
+}
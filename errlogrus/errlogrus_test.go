@@ -0,0 +1,54 @@
+package errlogrus_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/errlogrus"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+func ExampleFields() {
+	_, span := trace.StartSpan(context.Background(), "foo")
+	defer span.End()
+
+	err := errors.NewT(span, "a")
+	fields := errlogrus.Fields(err)
+	fmt.Println(fields["logging.googleapis.com/spanId"] == span.SpanContext().SpanID.String())
+	fmt.Println(fields["logging.googleapis.com/sourceLocation"].(errors.SourceLocation).Function)
+
+	// Output:
+	// true
+	// github.com/bzon/errors/errlogrus_test.ExampleFields
+}
+
+func ExampleHook() {
+	logger := logrus.New()
+	logger.SetOutput(os.Stdout)
+	logger.SetFormatter(&logrus.TextFormatter{DisableTimestamp: true})
+	logger.AddHook(errlogrus.Hook{})
+	// Keep the output stable.
+	logger.AddHook(keep("severity"))
+
+	logger.WithError(errors.New("a")).Error("failed")
+
+	// Output:
+	// level=error msg=failed error=a severity=ERROR
+}
+
+// keep is a hook that removes the fields of entries other than the error and the given key.
+type keep string
+
+func (keep) Levels() []logrus.Level { return logrus.AllLevels }
+
+func (k keep) Fire(entry *logrus.Entry) error {
+	for key := range entry.Data {
+		if key != logrus.ErrorKey && key != string(k) {
+			delete(entry.Data, key)
+		}
+	}
+	return nil
+}
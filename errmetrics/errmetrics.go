@@ -0,0 +1,89 @@
+// Package errmetrics counts errors by code, operation and creation site,
+// as an OpenCensus measure and as a Prometheus collector.
+//
+// Add Hook, or the Hook of a Collector, to count the errors when they are created:
+//
+//	errors.AddHook(errmetrics.Hook)
+//	view.Register(errmetrics.ErrorCountView)
+package errmetrics
+
+import (
+	"context"
+
+	"github.com/bzon/errors"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+// The tag keys of ErrorCount.
+var (
+	KeyCode     = tag.MustNewKey("code")
+	KeyOp       = tag.MustNewKey("op")
+	KeyFunction = tag.MustNewKey("function")
+)
+
+// ErrorCount is the measure of the number of errors.
+var ErrorCount = stats.Int64("github.com/bzon/errors/errors", "Number of errors", stats.UnitDimensionless)
+
+// ErrorCountView is the count of ErrorCount by code, operation and function.
+var ErrorCountView = &view.View{
+	Name:        "github.com/bzon/errors/error_count",
+	Description: "Number of errors by code, operation and function",
+	Measure:     ErrorCount,
+	Aggregation: view.Count(),
+	TagKeys:     []tag.Key{KeyCode, KeyOp, KeyFunction},
+}
+
+// labels are the code, operation and function of an error.
+type labels struct {
+	code     string
+	op       string
+	function string
+}
+
+// labelsOf returns the code of err, its outermost operation and the function of its innermost
+// ErrorTracer, where it was created.
+func labelsOf(err error) labels {
+	l := labels{code: errors.CodeOf(err).String()}
+	if ops := errors.Ops(err); len(ops) > 0 {
+		l.op = ops[0]
+	}
+	if frames := errors.FramesOf(err); len(frames) > 0 {
+		l.function = frames[len(frames)-1].Function
+	}
+	return l
+}
+
+// isNew reports whether e does not wrap another ErrorTracer, so that an error is counted
+// when it is created but not again when it is wrapped.
+func isNew(e errors.ErrorTracer) bool {
+	return len(errors.FramesOf(e)) == 1
+}
+
+// Record records err in ErrorCount, e.g. when it is reported.
+// It does nothing for a nil error.
+func Record(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+	l := labelsOf(err)
+	_ = stats.RecordWithTags(ctx,
+		[]tag.Mutator{
+			tag.Upsert(KeyCode, l.code),
+			tag.Upsert(KeyOp, l.op),
+			tag.Upsert(KeyFunction, l.function),
+		},
+		ErrorCount.M(1),
+	)
+}
+
+// Hook is an errors.Hook that records the created errors in ErrorCount.
+// The errors that wrap another ErrorTracer are not recorded again.
+// The code and operation are the ones of the error when it is created, use Record instead
+// when the error is reported to count it with the ones set afterwards, e.g. by errors.WithOp.
+func Hook(e errors.ErrorTracer) {
+	if isNew(e) {
+		Record(context.Background(), e)
+	}
+}
@@ -0,0 +1,29 @@
+package errmetrics_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/errmetrics"
+	"go.opencensus.io/stats/view"
+)
+
+func ExampleHook() {
+	_ = view.Register(errmetrics.ErrorCountView)
+	defer view.Unregister(errmetrics.ErrorCountView)
+	errors.AddHook(errmetrics.Hook)
+	defer errors.ResetHooks()
+
+	for i := 0; i < 2; i++ {
+		err := errors.NewO("user not found", errors.NotFound)
+		_ = errors.WithOp(errors.Wrap(err, "get user"), "GetUser")
+	}
+
+	rows, _ := view.RetrieveData(errmetrics.ErrorCountView.Name)
+	for _, row := range rows {
+		fmt.Println(row.Tags, row.Data.(*view.CountData).Value)
+	}
+
+	// Output:
+	// [{{code} NOT_FOUND} {{function} github.com/bzon/errors/errmetrics_test.ExampleHook}] 2
+}
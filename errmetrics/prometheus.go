@@ -0,0 +1,56 @@
+package errmetrics
+
+import (
+	"github.com/bzon/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector is a Prometheus collector of the errors_total counter of errors by code, operation and function.
+//
+//	c := errmetrics.NewCollector()
+//	prometheus.MustRegister(c)
+//	errors.AddHook(c.Hook)
+type Collector struct {
+	errors *prometheus.CounterVec
+}
+
+// Compile time implementation check.
+var _ prometheus.Collector = &Collector{}
+
+// NewCollector creates a Collector.
+func NewCollector() *Collector {
+	return &Collector{
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "errors_total",
+			Help: "Number of errors by code, operation and function.",
+		}, []string{"code", "op", "function"}),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.errors.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.errors.Collect(ch)
+}
+
+// Record counts err, e.g. when it is reported.
+// It does nothing for a nil error.
+func (c *Collector) Record(err error) {
+	if err == nil {
+		return
+	}
+	l := labelsOf(err)
+	c.errors.WithLabelValues(l.code, l.op, l.function).Inc()
+}
+
+// Hook is an errors.Hook that counts the created errors.
+// The errors that wrap another ErrorTracer are not counted again, see the Hook function.
+func (c *Collector) Hook(e errors.ErrorTracer) {
+	if isNew(e) {
+		c.Record(e)
+	}
+}
@@ -0,0 +1,28 @@
+package errmetrics_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/errmetrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func ExampleCollector() {
+	c := errmetrics.NewCollector()
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(c)
+	errors.AddHook(c.Hook)
+	defer errors.ResetHooks()
+
+	err := errors.WithOp(errors.NewO("user not found", errors.NotFound), "GetUser")
+	_ = errors.Wrap(err, "get user")
+
+	fmt.Println(testutil.CollectAndCount(c, "errors_total"))
+	fmt.Println(testutil.ToFloat64(c))
+
+	// Output:
+	// 1
+	// 1
+}
@@ -3,13 +3,14 @@ package errors
 import (
 	"fmt"
 	"runtime"
+	"sync/atomic"
+	"time"
 
 	"errors"
-
-	"go.opencensus.io/trace"
 )
 
 // Overwrite these values during build via -ldflags.
+// VERSION and COMMIT default to the build information embedded in the binary, see readBuildInfo.
 var (
 	// VERSION is the app-global version.
 	VERSION = "UNKNOWN"
@@ -26,10 +27,20 @@ const (
 )
 
 // Tracer represents an error that has TraceContext and SourceLocation.
+//
+// The errors of this package are immutable once created, the With* functions return annotated copies.
+// SetTraceContext and SetSourceLocation are the exception: they modify the error in place,
+// and must only be called before it is shared, e.g. with other goroutines or as a sentinel error.
+// WithSpanContext and WithCallerLocation are their copy-on-write counterparts.
+//
+// The errors of this package carry more, that is read with IDOf, TimeOf, StackOf, FramesOf, StackTraceOf,
+// AppFramesOf, SnippetOf and RuntimeOf, so that other errors can implement Tracer.
 type Tracer interface {
 	SourceLocation() SourceLocation
 	TraceContext() TraceContext
-	SetTraceContext(trace.SpanContext)
+	// SetTraceContext sets the trace context in place, see WithSpanContext.
+	SetTraceContext(SpanContext)
+	// SetSourceLocation sets the source location in place, see WithCallerLocation.
 	SetSourceLocation(depth int)
 }
 
@@ -40,6 +51,8 @@ type Tracer interface {
 type TraceContext struct {
 	TraceID string `json:"trace"`
 	SpanID  string `json:"spanId"`
+	// TraceFlags are the W3C trace flags, see TraceParent.
+	TraceFlags byte `json:"traceFlags,omitempty"`
 }
 
 // SourceLocation provides the information where the actual error happened in the code.
@@ -53,12 +66,10 @@ type SourceLocation struct {
 	Branch   string `json:"branch,omitempty"`
 }
 
-// NewSourceLocation creates a SourceLocation using stdlib runtime.Caller.
+// NewSourceLocation creates a SourceLocation using stdlib runtime.Callers.
+// The depth is the same as the skip of runtime.Caller.
 func NewSourceLocation(depth int) SourceLocation {
-	function, file, line, _ := runtime.Caller(depth)
-	return SourceLocation{
-		runtime.FuncForPC(function).Name(), file, line, VERSION, COMMIT, BRANCH,
-	}
+	return captureLocation(depth + 1).get()
 }
 
 // As is a drop-in replacement for errors.As method.
@@ -66,6 +77,30 @@ func As(target error, dest interface{}) bool {
 	return errors.As(target, dest)
 }
 
+// AsType finds the first error in the chain of err that is of type T, like As.
+//
+//	if e, ok := errors.AsType[*os.PathError](err); ok {
+func AsType[T error](err error) (T, bool) {
+	// A type assertion is much cheaper than the reflection of errors.As.
+	var t T
+	ok := walk(err, func(err error) bool {
+		if v, ok := err.(T); ok {
+			t = v
+			return true
+		}
+		if x, ok := err.(interface{ As(interface{}) bool }); ok && x.As(&t) {
+			return true
+		}
+		return false
+	})
+	return t, ok
+}
+
+// TracerOf returns the outermost ErrorTracer in the chain of err.
+func TracerOf(err error) (ErrorTracer, bool) {
+	return AsType[ErrorTracer](err)
+}
+
 // Is is a drop-in replacement for errors.Is method.
 func Is(err, target error) bool {
 	return errors.Is(err, target)
@@ -85,8 +120,73 @@ var _ ErrorTracer = &errorContext{}
 
 type errorContext struct {
 	err            error
-	sourceLocation SourceLocation
+	sourceLocation location
 	traceContext   TraceContext
+	code           Code
+	httpStatus     int
+	httpRequest    *HTTPRequest
+	stack          []uintptr
+	fields         Fields
+	details        []interface{}
+	op             string
+	severity       Severity
+	retryable      tristate
+	publicMessage  string
+	timeout        tristate
+	temporary      tristate
+	// format is the format of the message, when it is created with a format, see Fingerprint.
+	format     string
+	id         uint64
+	occurredAt time.Time
+	// runtime is set when Options.CaptureRuntime is, see Runtime.
+	runtime *Runtime
+	// pending is set when the annotation of e is deferred until it is logged, see Options.DeferAnnotation.
+	// It is shared by the copies of e, that are annotated once.
+	pending *pendingAnnotation
+	// sentinel is set for the errors created by Sentinel, that have no source location, ID or time of their own.
+	sentinel bool
+	// atOrigin is set for the errors that have the source location of the origin of their chain, see AtOrigin.
+	atOrigin bool
+	// remote is set for errors unmarshaled from another process, see Unmarshal.
+	remote *remoteContext
+	// mutable are the fields set in place by SetSourceLocation and SetTraceContext, see newContext.
+	mutable *mutableFields
+}
+
+// mutableFields override the source location and the trace context of an error once they are set in place.
+// They are read and written atomically, so that the setters do not race with the readers of a shared error,
+// without a lock. The other fields are never modified once the error is created.
+type mutableFields struct {
+	sourceLocation atomic.Pointer[location]
+	traceContext   atomic.Pointer[TraceContext]
+}
+
+// contextAlloc allocates an errorContext along with its mutableFields.
+type contextAlloc struct {
+	e errorContext
+	m mutableFields
+}
+
+// newContext returns a copy of e with mutableFields of its own, in a single allocation.
+func newContext(e errorContext) *errorContext {
+	a := &contextAlloc{e: e}
+	a.e.mutable = &a.m
+	return &a.e
+}
+
+// newErrorContext creates an errorContext for err with a new ID and the current time.
+// The runtime metadata is captured when Options.CaptureRuntime is set.
+func newErrorContext(err error, loc location) *errorContext {
+	e := newContext(errorContext{
+		err:            err,
+		sourceLocation: loc,
+		id:             nextID(),
+		occurredAt:     time.Now(),
+	})
+	if !traceDisabled && config().CaptureRuntime {
+		e.runtime = captureRuntime()
+	}
+	return e
 }
 
 func (e *errorContext) Unwrap() error {
@@ -98,201 +198,264 @@ func (e *errorContext) Error() string {
 }
 
 func (e *errorContext) SourceLocation() SourceLocation {
+	return e.location().get()
+}
+
+// location returns the source location, that SetSourceLocation may modify concurrently.
+func (e *errorContext) location() location {
+	if loc := e.mutable.sourceLocation.Load(); loc != nil {
+		return *loc
+	}
 	return e.sourceLocation
 }
 
 func (e *errorContext) SetSourceLocation(depth int) {
-	e.sourceLocation = NewSourceLocation(depth)
+	loc := captureLocation(depth)
+	e.mutable.sourceLocation.Store(&loc)
 }
 
-func (e *errorContext) TraceContext() TraceContext {
-	return e.traceContext
+// StackOf returns the call stack captured with the outermost error of this package in the chain of err, if any,
+// see WithStack.
+func StackOf(err error) []SourceLocation {
+	if e := contextOf(err); e != nil {
+		return e.Stack()
+	}
+	return nil
 }
 
-func (e *errorContext) SetTraceContext(t trace.SpanContext) {
-	e.traceContext = TraceContext{
-		TraceID: t.TraceID.String(),
-		SpanID:  t.SpanID.String(),
+// Stack returns the call stack captured with the error, if any.
+func (e *errorContext) Stack() []SourceLocation {
+	if e.remote != nil {
+		return e.remote.stack
+	}
+	if len(e.stack) == 0 {
+		return nil
+	}
+	stack := make([]SourceLocation, 0, len(e.stack))
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := frames.Next()
+		stack = append(stack, SourceLocation{
+			frame.Function, trimPath(frame.Function, frame.File), frame.Line, VERSION, COMMIT, BRANCH,
+		})
+		if !more {
+			return stack
+		}
 	}
 }
 
-// NewCaller wraps errors.New with a specified caller depth.
-func NewCaller(depth int, m string) error {
-	err := &errorContext{
-		err:            errors.New(m),
-		sourceLocation: NewSourceLocation(depth),
+// FramesOf returns the source locations of every layer of the chain of the outermost error of this package
+// in the chain of err, outermost first. Each Wrap adds a frame to the chain of the error it wraps.
+func FramesOf(err error) []SourceLocation {
+	if e := contextOf(err); e != nil {
+		return e.Frames()
 	}
-	return err
+	return nil
 }
 
-// NewCallerT wraps errors.New with a specified caller depth and a span trace context.
-func NewCallerT(depth int, span *trace.Span, m string) error {
-	err := &errorContext{
-		err:            errors.New(m),
-		sourceLocation: NewSourceLocation(depth),
-	}
-	return annotate(err, span)
+// Frames returns the source locations of every layer of the error chain, outermost first.
+func (e *errorContext) Frames() []SourceLocation {
+	var frames []SourceLocation
+	visit(e, func(c *errorContext) bool {
+		if c.sentinel || c.atOrigin {
+			return false
+		}
+		frames = append(frames, c.SourceLocation())
+		return false
+	})
+	return frames
 }
 
-// NewCallerf wraps fmt.Errorf with a specified caller depth.
-func NewCallerf(depth int, m string, args ...interface{}) error {
-	err := &errorContext{
-		err:            fmt.Errorf(m, args...),
-		sourceLocation: NewSourceLocation(depth),
+func (e *errorContext) TraceContext() TraceContext {
+	if tc := e.mutable.traceContext.Load(); tc != nil {
+		return *tc
 	}
-	return err
+	return e.traceContext
+}
+
+func (e *errorContext) SetTraceContext(t SpanContext) {
+	tc := newTraceContext(t)
+	e.mutable.traceContext.Store(&tc)
 }
 
-// NewCallerfT wraps fmt.Errorf with a specified caller depth and a span trace context.
-func NewCallerfT(depth int, span *trace.Span, m string, args ...interface{}) error {
-	err := &errorContext{
-		err:            fmt.Errorf(m, args...),
-		sourceLocation: NewSourceLocation(depth),
+// clone returns a copy of e, with the source location and the trace context that are set in place.
+func (e *errorContext) clone() *errorContext {
+	c := *e
+	c.sourceLocation = e.location()
+	c.traceContext = e.TraceContext()
+	return newContext(c)
+}
+
+// withContext returns a copy of err when it is an *errorContext.
+// Any other error is wrapped, keeping its message, with the source location at the given depth.
+func withContext(err error, depth int) *errorContext {
+	if e, ok := err.(*errorContext); ok && e.sentinel {
+		return e.use(captureLocation(depth + 1))
+	}
+	if e, ok := err.(*errorContext); ok {
+		return e.clone()
 	}
-	return annotate(err, span)
+	return newErrorContext(err, captureLocation(depth+1))
 }
 
-// WrapCaller wraps fmt.Errorf with a specified caller depth.
-func WrapCaller(depth int, e error, m string) error {
-	err := &errorContext{
-		err:            fmt.Errorf("%s: %w", m, e),
-		sourceLocation: NewSourceLocation(depth),
+// visit calls fn for every *errorContext in the chain of err, outermost first, until fn returns true.
+// Multi-errors, that implement Unwrap() []error, are visited depth-first.
+// It reports whether fn returned true.
+func visit(err error, fn func(*errorContext) bool) bool {
+	return walk(err, func(err error) bool {
+		e, ok := err.(*errorContext)
+		return ok && fn(e)
+	})
+}
+
+// walk calls fn for every error in the chain of err, like visit.
+// It stops when the chain loops back to an error it already had, and after Options.MaxChainDepth wraps.
+func walk(err error, fn func(error) bool) bool {
+	n := maxChainDepth()
+	return walkN(err, fn, &n)
+}
+
+// walkN is walk over at most *n wraps. It decrements *n for every wrap, see isWrap.
+// The cycles are detected along the chain of every branch of the multi-errors.
+func walkN(err error, fn func(error) bool, n *int) bool {
+	var cycle chainCycle
+	for err != nil && *n > 0 && !cycle.seen(err) {
+		if isWrap(err) {
+			*n--
+		}
+		if fn(err) {
+			return true
+		}
+		if m, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, err := range m.Unwrap() {
+				if walkN(err, fn, n) {
+					return true
+				}
+			}
+			return false
+		}
+		err = errors.Unwrap(err)
 	}
-	return err
+	return false
+}
+
+// NewCaller wraps errors.New with a specified caller depth.
+func NewCaller(depth int, m string) error {
+	err := newErrorContext(errors.New(m), captureLocation(depth))
+	return created(err, nil)
 }
 
-// WrapCallerT wraps fmt.Errorf with a specified caller depth with a span trace context.
-func WrapCallerT(depth int, span *trace.Span, e error, m string) error {
-	err := &errorContext{
-		err:            fmt.Errorf("%s: %w", m, e),
-		sourceLocation: NewSourceLocation(depth),
+// NewCallerf wraps fmt.Errorf with a specified caller depth.
+func NewCallerf(depth int, m string, args ...interface{}) error {
+	err := newErrorContext(fmt.Errorf(m, args...), captureLocation(depth))
+	err.format = m
+	return created(err, nil)
+}
+
+// WrapCaller wraps fmt.Errorf with a specified caller depth.
+// It returns nil if e is nil.
+func WrapCaller(depth int, e error, m string) error {
+	if e == nil {
+		return nil
 	}
-	return annotate(err, span)
+	err := newErrorContext(wrapMessage(e, m), captureLocation(depth))
+	return created(err, nil)
 }
 
 // WrapCallerf wraps fmt.Errorf with a specified caller depth.
+// It returns nil if e is nil.
 func WrapCallerf(depth int, e error, format string, args ...interface{}) error {
-	m := fmt.Sprintf(format, args...)
-	err := &errorContext{
-		err:            fmt.Errorf("%s: %w", m, e),
-		sourceLocation: NewSourceLocation(depth),
+	if e == nil {
+		return nil
 	}
-	return err
-}
-
-// WrapCallerfT wraps fmt.Errorf with a specified caller depth with a span trace context.
-func WrapCallerfT(depth int, span *trace.Span, e error, format string, args ...interface{}) error {
 	m := fmt.Sprintf(format, args...)
-	err := &errorContext{
-		err:            fmt.Errorf("%s: %w", m, e),
-		sourceLocation: NewSourceLocation(depth),
-	}
-	return annotate(err, span)
+	err := newErrorContext(wrapMessage(e, m), captureLocation(depth))
+	return created(err, nil)
 }
 
 // New is the drop-in replacement for errors.New.
 func New(m string) error {
-	err := &errorContext{
-		err:            errors.New(m),
-		sourceLocation: NewSourceLocation(wrappedFunctionCallDepth),
-	}
-	return err
-}
-
-// NewT wraps errors.New with a span trace context.
-func NewT(span *trace.Span, m string) error {
-	err := &errorContext{
-		err:            errors.New(m),
-		sourceLocation: NewSourceLocation(wrappedFunctionCallDepth),
-	}
-	return annotate(err, span)
+	err := newErrorContext(errors.New(m), captureLocation(wrappedFunctionCallDepth))
+	return created(err, nil)
 }
 
 // Errorf wraps fmt.Errorf.
 func Errorf(m string, args ...interface{}) error {
-	err := &errorContext{
-		err:            fmt.Errorf(m, args...),
-		sourceLocation: NewSourceLocation(wrappedFunctionCallDepth),
-	}
-	return err
-}
-
-// ErrorfT wraps fmt.Errorf with a span trace context.
-func ErrorfT(span *trace.Span, m string, args ...interface{}) error {
-	err := &errorContext{
-		err:            fmt.Errorf(m, args...),
-		sourceLocation: NewSourceLocation(wrappedFunctionCallDepth),
-	}
-	return annotate(err, span)
+	err := newErrorContext(fmt.Errorf(m, args...), captureLocation(wrappedFunctionCallDepth))
+	err.format = m
+	return created(err, nil)
 }
 
 // Wrap wraps an error fmt.Errorf with `%w` without formatting.
+// It returns nil if e is nil.
 func Wrap(e error, m string) error {
-	err := &errorContext{
-		err:            fmt.Errorf("%s: %w", m, e),
-		sourceLocation: NewSourceLocation(wrappedFunctionCallDepth),
-	}
-	return err
-}
-
-// WrapT wraps an error with a span trace context.
-func WrapT(span *trace.Span, e error, m string) error {
-	err := &errorContext{
-		err:            fmt.Errorf("%s: %w", m, e),
-		sourceLocation: NewSourceLocation(wrappedFunctionCallDepth),
+	if e == nil {
+		return nil
 	}
-	return annotate(err, span)
+	err := newErrorContext(wrapMessage(e, m), captureLocation(wrappedFunctionCallDepth))
+	return created(err, nil)
 }
 
 // Wrap wraps fmt.Errorf with `%w` with formatting.
+// It returns nil if e is nil.
 func Wrapf(e error, f string, args ...interface{}) error {
-	m := fmt.Sprintf(f, args...)
-	err := &errorContext{
-		err:            fmt.Errorf("%s: %w", m, e),
-		sourceLocation: NewSourceLocation(wrappedFunctionCallDepth),
+	if e == nil {
+		return nil
 	}
-	return err
-}
-
-// WrapfT is Wrapf with a trace context.
-func WrapfT(span *trace.Span, e error, f string, args ...interface{}) error {
 	m := fmt.Sprintf(f, args...)
-	err := &errorContext{
-		err:            fmt.Errorf("%s: %w", m, e),
-		sourceLocation: NewSourceLocation(wrappedFunctionCallDepth),
-	}
-	return annotate(err, span)
+	err := newErrorContext(wrapMessage(e, m), captureLocation(wrappedFunctionCallDepth))
+	return created(err, nil)
 }
 
-func annotate(e *errorContext, span *trace.Span) error {
-	if span == nil {
+// annotate sets the trace context of e from the span of a, and annotates e on the span unless it is not sampled.
+// With Options.DeferAnnotation, the annotation is deferred until e is logged, see AnnotateDeferred.
+func annotate(e *errorContext, a SpanAnnotator) error {
+	if a == nil || traceDisabled {
 		return e
 	}
 
 	// Add the trace ID and span ID.
-	ctx := span.SpanContext()
-	e.traceContext = TraceContext{
-		TraceID: ctx.TraceID.String(),
-		SpanID:  ctx.SpanID.String(),
+	e.traceContext = a.TraceContext()
+	// Spans that are not sampled are not exported, skip the annotation.
+	if !e.traceContext.Sampled() {
+		return e
 	}
-
-	// Add OpenCensus span annotation.
-	src := e.SourceLocation()
-	span.Annotate(
-		[]trace.Attribute{
-			trace.StringAttribute("function", src.Function),
-			trace.StringAttribute("file", src.File),
-			trace.Int64Attribute("line", int64(src.Line)),
-			trace.StringAttribute("version", src.Version),
-			trace.StringAttribute("commit", src.Commit),
-			trace.StringAttribute("branch", src.Branch),
-		},
-		"Error: "+e.Error(),
-	)
-
-	// Generic error
-	span.SetStatus(trace.Status{
-		Code: trace.StatusCodeUnknown,
-	})
+	if config().DeferAnnotation {
+		e.pending = &pendingAnnotation{annotator: a}
+		return e
+	}
+	annotateSpan(e, a)
 	return e
 }
+
+// annotateSpan annotates e on the span of a,
+// unless Options.ShouldAnnotate, Options.AnnotateLimit or Options.SpanAnnotationLimit skips it.
+func annotateSpan(e *errorContext, a SpanAnnotator) {
+	o := config()
+	if f := o.ShouldAnnotate; f != nil && !f(e) {
+		return
+	}
+	msg := RedactedMessage(e)
+	if o.AnnotateLimit.enabled() {
+		ok, skipped := sample(o.AnnotateLimit, Fingerprint(e), time.Now())
+		if !ok {
+			return
+		}
+		msg += skippedSuffix(skipped)
+	}
+	if o.SpanAnnotationLimit > 0 && !spend(e, o.SpanAnnotationLimit, time.Now()) {
+		return
+	}
+	if la, ok := a.(LinkAnnotator); ok && e.TraceContext().TraceID != "" {
+		for _, tc := range links(e.err, e.TraceContext().TraceID) {
+			la.AddLink(tc)
+		}
+	}
+	if fa, ok := a.(FieldsAnnotator); ok {
+		if fields := fieldAttributes(e, o.FieldAttributePrefix); len(fields) > 0 {
+			fa.AnnotateErrorFields(msg, e.SourceLocation(), CodeOf(e), fields)
+			return
+		}
+	}
+	a.AnnotateError(msg, e.SourceLocation(), CodeOf(e))
+}
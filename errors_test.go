@@ -34,6 +34,31 @@ func ExampleAs() {
 	// err
 }
 
+func ExampleAsType() {
+	wrappedErr := errors.Wrap(&customError{Err: "err"}, "wrapped")
+
+	if e, ok := errors.AsType[*customError](wrappedErr); ok {
+		fmt.Println(e.Err)
+	}
+
+	// Output:
+	// err
+}
+
+func ExampleTracerOf() {
+	wrappedErr := fmt.Errorf("wrapped: %w", errors.New("err"))
+
+	if e, ok := errors.TracerOf(wrappedErr); ok {
+		fmt.Println(e.SourceLocation().Function)
+	}
+	_, ok := errors.TracerOf(errSentinel)
+	fmt.Println(ok)
+
+	// Output:
+	// github.com/bzon/errors_test.ExampleTracerOf
+	// false
+}
+
 func ExampleIs() {
 	err := errSentinel
 	wrappedErr := errors.Wrap(err, "wrapped")
@@ -91,14 +116,14 @@ func ExampleWrapf() {
 	// github.com/bzon/errors_test.ExampleWrapf
 }
 
-// func ExampleCause() {
-// 	err := errors.New("a")
-// 	err = errors.Wrap(err, "b")
-// 	cause := errors.Cause(err)
-// 	fmt.Println(cause)
+func ExampleCause() {
+	err := errors.New("a")
+	err = errors.Wrap(err, "b")
+	cause := errors.Cause(err)
+	fmt.Println(cause)
 
-// 	// Output: a
-// }
+	// Output: a
+}
 
 func ExampleNewT() {
 	_, span := trace.StartSpan(context.Background(), "foo")
@@ -291,3 +316,33 @@ func ExampleTraceContext() {
 	// 61626300000000000000000000000000
 	// 6465660000000000
 }
+
+func getUser() error {
+	return errors.New("not found")
+}
+
+func ExampleErrorTracer_frames() {
+	err := getUser()
+	err = errors.Wrap(err, "get user")
+	err = fmt.Errorf("handler: %w", err)
+	err = errors.Wrapf(err, "request %d", 1)
+
+	e := err.(errors.ErrorTracer)
+	for _, frame := range errors.FramesOf(e) {
+		fmt.Println(frame.Function)
+	}
+
+	// Output:
+	// github.com/bzon/errors_test.ExampleErrorTracer_frames
+	// github.com/bzon/errors_test.ExampleErrorTracer_frames
+	// github.com/bzon/errors_test.getUser
+}
+
+func ExampleWrap_nil() {
+	var err error
+	err = errors.Wrap(err, "b")
+	fmt.Println(err == nil)
+
+	// Output:
+	// true
+}
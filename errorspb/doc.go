@@ -0,0 +1,4 @@
+// Package errorspb contains the protocol buffers of the errors encoded by errors.MarshalProto.
+package errorspb
+
+//go:generate protoc --proto_path=.. --go_out=.. --go_opt=paths=source_relative errorspb/errors.proto
@@ -0,0 +1,117 @@
+// Package errotel annotates errors on OpenTelemetry spans.
+//
+//	err := errors.NewO("user not found", errors.NotFound, errotel.WithSpan(span))
+package errotel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bzon/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// annotator is the errors.SpanAnnotator of an OpenTelemetry span.
+type annotator struct {
+	span trace.Span
+}
+
+// Span returns the errors.SpanAnnotator of span, or nil if span is nil.
+// The errors are recorded as exception events, with the OpenTelemetry semantic conventions.
+func Span(span trace.Span) errors.SpanAnnotator {
+	if span == nil {
+		return nil
+	}
+	return annotator{span: span}
+}
+
+// WithSpan annotates the error on span, see Span.
+func WithSpan(span trace.Span) errors.Option {
+	return errors.WithSpanAnnotator(Span(span))
+}
+
+// WithContext annotates the error on the span of ctx, see Span.
+func WithContext(ctx context.Context) errors.Option {
+	return WithSpan(trace.SpanFromContext(ctx))
+}
+
+func (a annotator) TraceContext() errors.TraceContext {
+	sc := a.span.SpanContext()
+	if !sc.IsValid() {
+		return errors.TraceContext{}
+	}
+	return errors.TraceContext{
+		TraceID:    sc.TraceID().String(),
+		SpanID:     sc.SpanID().String(),
+		TraceFlags: byte(sc.TraceFlags()),
+	}
+}
+
+func (a annotator) AnnotateError(msg string, src errors.SourceLocation, code errors.Code) {
+	a.AnnotateErrorFields(msg, src, code, nil)
+}
+
+func (a annotator) AnnotateErrorFields(msg string, src errors.SourceLocation, code errors.Code, fields []errors.Field) {
+	a.span.AddEvent("exception", trace.WithAttributes(
+		attribute.String("exception.type", code.String()),
+		attribute.String("exception.message", msg),
+		attribute.String("code.function", src.Function),
+		attribute.String("code.filepath", src.File),
+		attribute.Int("code.lineno", src.Line),
+	))
+	// Set the fields as span attributes, to query them in the tracing backend.
+	if len(fields) > 0 {
+		attrs := make([]attribute.KeyValue, len(fields))
+		for i, f := range fields {
+			attrs[i] = keyValue(f)
+		}
+		a.span.SetAttributes(attrs...)
+	}
+	if code != errors.OK {
+		a.span.SetStatus(codes.Error, msg)
+	}
+}
+
+// AddLink links the span to the remote span of tc. The trace contexts that are not valid are skipped.
+func (a annotator) AddLink(tc errors.TraceContext) {
+	traceID, err := trace.TraceIDFromHex(tc.TraceID)
+	if err != nil {
+		return
+	}
+	spanID, err := trace.SpanIDFromHex(tc.SpanID)
+	if err != nil {
+		return
+	}
+	a.span.AddLink(trace.Link{SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.TraceFlags(tc.TraceFlags),
+		Remote:     true,
+	})})
+}
+
+// keyValue converts f to an OpenTelemetry attribute.
+// Durations and times are formatted as strings, and the values of other types with fmt.Sprint.
+func keyValue(f errors.Field) attribute.KeyValue {
+	switch v := f.Value.(type) {
+	case string:
+		return attribute.String(f.Key, v)
+	case bool:
+		return attribute.Bool(f.Key, v)
+	case int64:
+		return attribute.Int64(f.Key, v)
+	case int:
+		return attribute.Int(f.Key, v)
+	case float64:
+		return attribute.Float64(f.Key, v)
+	case time.Duration:
+		return attribute.String(f.Key, v.String())
+	case time.Time:
+		return attribute.String(f.Key, v.Format(time.RFC3339Nano))
+	default:
+		return attribute.String(f.Key, fmt.Sprint(v))
+	}
+}
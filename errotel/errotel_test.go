@@ -0,0 +1,73 @@
+package errotel_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/errotel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func ExampleWithSpan() {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	_, span := tp.Tracer("example").Start(context.Background(), "GetUser")
+
+	err := errors.NewO("user not found", errors.NotFound, errotel.WithSpan(span))
+	span.End()
+
+	fmt.Println(err.(errors.ErrorTracer).TraceContext().SpanID == span.SpanContext().SpanID().String())
+	s := recorder.Ended()[0]
+	fmt.Println(s.Status().Code, s.Status().Description)
+	for _, attr := range s.Events()[0].Attributes {
+		if attr.Key == "exception.type" || attr.Key == "code.function" {
+			fmt.Println(attr.Key, attr.Value.Emit())
+		}
+	}
+
+	// Output:
+	// true
+	// Error user not found
+	// exception.type NOT_FOUND
+	// code.function github.com/bzon/errors/errotel_test.ExampleWithSpan
+}
+
+func ExampleSpan_fields() {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	_, span := tp.Tracer("example").Start(context.Background(), "GetUser")
+
+	_ = errors.NewO("user not found", errors.NotFound, errors.Str("user", "jb"), errotel.WithSpan(span))
+	span.End()
+
+	for _, attr := range recorder.Ended()[0].Attributes() {
+		fmt.Println(attr.Key, attr.Value.Emit())
+	}
+
+	// Output:
+	// error.field.user jb
+}
+
+func ExampleSpan_links() {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	_, span := tp.Tracer("example").Start(context.Background(), "GetUser")
+
+	// An error of another service, with its trace context.
+	remote := errors.NewO("user not found", errors.WithTraceContext(errors.TraceContext{
+		TraceID:    "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:     "00f067aa0ba902b7",
+		TraceFlags: 1,
+	}))
+	_ = errors.WrapO(remote, "get user", errotel.WithSpan(span))
+	span.End()
+
+	for _, link := range recorder.Ended()[0].Links() {
+		fmt.Println(link.SpanContext.TraceID(), link.SpanContext.SpanID(), link.SpanContext.IsRemote())
+	}
+
+	// Output:
+	// 4bf92f3577b34da6a3ce929d0e0e4736 00f067aa0ba902b7 true
+}
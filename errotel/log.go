@@ -0,0 +1,123 @@
+package errotel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bzon/errors"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// loggerName is the instrumentation scope of the log records of NewLogReporter.
+const loggerName = "github.com/bzon/errors/errotel"
+
+// NewLogReporter returns an errors.Reporter that emits the errors as OpenTelemetry log records,
+// with a logger of provider, e.g. a LoggerProvider of go.opentelemetry.io/otel/sdk/log with an OTLP exporter.
+// It is a vendor-neutral alternative to the Stackdriver fields.
+//
+// A record has the time when the error occurred, the severity and the scrubbed message of the error,
+// its trace context, and the exception and code attributes of the OpenTelemetry semantic conventions,
+// with the ID, the fingerprint and the fields of the error, see errors.FieldAttributes.
+func NewLogReporter(provider log.LoggerProvider) errors.Reporter {
+	logger := provider.Logger(loggerName)
+	return errors.ReporterFunc(func(ctx context.Context, errs []error) error {
+		for _, err := range errs {
+			logger.Emit(recordContext(ctx, err), Record(err))
+		}
+		return nil
+	})
+}
+
+// Record converts err to an OpenTelemetry log record, see NewLogReporter.
+// The trace context of err is not part of the record, but of the context passed to log.Logger.Emit.
+func Record(err error) log.Record {
+	errors.AnnotateDeferred(err)
+	var r log.Record
+	r.SetObservedTimestamp(time.Now())
+	severity := errors.SeverityOf(err)
+	r.SetSeverity(logSeverity(severity))
+	r.SetSeverityText(severity.String())
+	r.SetBody(log.StringValue(errors.RedactedMessage(err)))
+	r.AddAttributes(
+		log.String("exception.type", errors.CodeOf(err).String()),
+		log.String("exception.message", errors.RedactedMessage(err)),
+		log.String("error.fingerprint", errors.Fingerprint(err)),
+	)
+	if e, ok := errors.TracerOf(err); ok {
+		r.SetTimestamp(errors.TimeOf(e))
+		src := e.SourceLocation()
+		r.AddAttributes(
+			log.String("error.id", errors.IDOf(e)),
+			log.String("code.function", src.Function),
+			log.String("code.filepath", src.File),
+			log.Int("code.lineno", src.Line),
+		)
+	}
+	for _, f := range errors.FieldAttributes(err) {
+		r.AddAttributes(logKeyValue(f))
+	}
+	return r
+}
+
+// recordContext returns a copy of ctx with the span context of the outermost trace context in the chain of err,
+// for the log record to be correlated with the trace.
+func recordContext(ctx context.Context, err error) context.Context {
+	var tc errors.TraceContext
+	errors.Walk(err, func(err error) bool {
+		if e, ok := err.(errors.ErrorTracer); ok {
+			tc = e.TraceContext()
+		}
+		return tc.TraceID == ""
+	})
+	traceID, terr := trace.TraceIDFromHex(tc.TraceID)
+	spanID, serr := trace.SpanIDFromHex(tc.SpanID)
+	if terr != nil || serr != nil {
+		return ctx
+	}
+	return trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.TraceFlags(tc.TraceFlags),
+		Remote:     true,
+	}))
+}
+
+// logSeverity converts s to the severity number of OpenTelemetry log records.
+func logSeverity(s errors.Severity) log.Severity {
+	switch s {
+	case errors.SeverityDebug:
+		return log.SeverityDebug
+	case errors.SeverityInfo:
+		return log.SeverityInfo
+	case errors.SeverityWarning:
+		return log.SeverityWarn
+	case errors.SeverityCritical:
+		return log.SeverityFatal
+	default:
+		return log.SeverityError
+	}
+}
+
+// logKeyValue converts f to an OpenTelemetry log attribute, like keyValue.
+func logKeyValue(f errors.Field) log.KeyValue {
+	switch v := f.Value.(type) {
+	case string:
+		return log.String(f.Key, v)
+	case bool:
+		return log.Bool(f.Key, v)
+	case int64:
+		return log.Int64(f.Key, v)
+	case int:
+		return log.Int(f.Key, v)
+	case float64:
+		return log.Float64(f.Key, v)
+	case time.Duration:
+		return log.String(f.Key, v.String())
+	case time.Time:
+		return log.String(f.Key, v.Format(time.RFC3339Nano))
+	default:
+		return log.String(f.Key, fmt.Sprint(v))
+	}
+}
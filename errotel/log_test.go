@@ -0,0 +1,48 @@
+package errotel_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/errotel"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// memoryExporter is a log exporter that keeps the records in memory.
+type memoryExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *memoryExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *memoryExporter) Shutdown(ctx context.Context) error   { return nil }
+func (e *memoryExporter) ForceFlush(ctx context.Context) error { return nil }
+
+func ExampleNewLogReporter() {
+	exporter := &memoryExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+
+	err := errors.NewO("user not found", errors.NotFound, errors.WithTraceContext(errors.TraceContext{
+		TraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:  "00f067aa0ba902b7",
+	}))
+	_ = errotel.NewLogReporter(provider).Report(context.Background(), []error{err})
+
+	r := exporter.records[0]
+	fmt.Println(r.SeverityText(), r.Body().AsString())
+	fmt.Println(r.TraceID(), r.SpanID())
+
+	// Output:
+	// ERROR user not found
+	// 4bf92f3577b34da6a3ce929d0e0e4736 00f067aa0ba902b7
+}
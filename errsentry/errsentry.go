@@ -0,0 +1,132 @@
+// Package errsentry reports errors to Sentry.
+//
+//	errors.SetReporter(errsentry.NewReporter(nil), errors.ReporterOptions{})
+package errsentry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bzon/errors"
+	"github.com/getsentry/sentry-go"
+)
+
+// flushTimeout is the time Report waits for the events to be sent when ctx has no deadline.
+const flushTimeout = 5 * time.Second
+
+var levels = map[errors.Severity]sentry.Level{
+	errors.SeverityDebug:    sentry.LevelDebug,
+	errors.SeverityInfo:     sentry.LevelInfo,
+	errors.SeverityWarning:  sentry.LevelWarning,
+	errors.SeverityError:    sentry.LevelError,
+	errors.SeverityCritical: sentry.LevelFatal,
+}
+
+// Reporter is an errors.Reporter that sends the errors to Sentry as events, see Event.
+type Reporter struct {
+	hub *sentry.Hub
+}
+
+// Compile time implementation check.
+var _ errors.Reporter = &Reporter{}
+
+// NewReporter creates a Reporter that sends the errors with hub.
+// The sentry.CurrentHub is used when hub is nil.
+func NewReporter(hub *sentry.Hub) *Reporter {
+	if hub == nil {
+		hub = sentry.CurrentHub()
+	}
+	return &Reporter{hub: hub}
+}
+
+// Report implements errors.Reporter.
+// It waits until the events are sent, or until the deadline of ctx.
+func (r *Reporter) Report(ctx context.Context, errs []error) error {
+	for _, err := range errs {
+		r.hub.CaptureEvent(Event(err))
+	}
+	timeout := flushTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	if !r.hub.Flush(timeout) {
+		return fmt.Errorf("errsentry: %d events not sent after %s", len(errs), timeout)
+	}
+	return nil
+}
+
+// Event converts err to a Sentry event.
+// The exception has the code of err as type and its message, scrubbed by the redactors, as value.
+// Its stack trace is the stack of the outermost ErrorTracer in the chain of err, or else its frames.
+// The fingerprint of the event is the fingerprint of err, see errors.Fingerprint.
+func Event(err error) *sentry.Event {
+	errors.AnnotateDeferred(err)
+	event := sentry.NewEvent()
+	event.Level = levels[errors.SeverityOf(err)]
+	event.Message = errors.RedactedMessage(err)
+	event.Fingerprint = []string{errors.Fingerprint(err)}
+	exception := sentry.Exception{
+		Type:  errors.CodeOf(err).String(),
+		Value: event.Message,
+	}
+	if fields := errors.RedactedFields(err); len(fields) > 0 {
+		event.Extra = fields
+	}
+	event.Tags["code"] = errors.CodeOf(err).String()
+	if ops := errors.Ops(err); len(ops) > 0 {
+		event.Tags["op"] = ops[0]
+	}
+
+	var e errors.ErrorTracer
+	if errors.As(err, &e) {
+		event.EventID = sentry.EventID(eventID(errors.IDOf(e)))
+		event.Timestamp = errors.TimeOf(e)
+		event.Tags["errorId"] = errors.IDOf(e)
+		if tc := e.TraceContext(); tc.TraceID != "" {
+			event.Contexts["trace"] = map[string]interface{}{
+				"trace_id": tc.TraceID,
+				"span_id":  tc.SpanID,
+			}
+		}
+		exception.Stacktrace = stacktrace(e)
+	}
+	event.Exception = []sentry.Exception{exception}
+	return event
+}
+
+// stacktrace returns the stack of e, or else its frames, oldest call first as Sentry expects.
+// The frames of the application, see errors.Options.AppPackages, are in app.
+func stacktrace(e errors.ErrorTracer) *sentry.Stacktrace {
+	var locations []errors.SourceLocation
+	if stack := errors.StackOf(e); len(stack) > 0 {
+		// The stack is innermost first.
+		for i := len(stack) - 1; i >= 0; i-- {
+			locations = append(locations, stack[i])
+		}
+	} else {
+		// The frames are outermost first, and the outermost error is created by a caller of the inner ones.
+		locations = errors.FramesOf(e)
+	}
+	st := &sentry.Stacktrace{}
+	for _, src := range locations {
+		st.Frames = append(st.Frames, sentry.Frame{
+			Function: src.Function,
+			AbsPath:  src.File,
+			Lineno:   src.Line,
+			InApp:    errors.IsAppFrame(src),
+		})
+	}
+	return st
+}
+
+// eventID returns the ID of an error as a Sentry event ID, a UUID without dashes.
+func eventID(id string) string {
+	b := make([]byte, 0, len(id))
+	for i := 0; i < len(id); i++ {
+		if id[i] != '-' {
+			b = append(b, id[i])
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,72 @@
+package errsentry_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/errsentry"
+	"github.com/getsentry/sentry-go"
+)
+
+func ExampleEvent() {
+	err := errors.WithSeverity(errors.NewO("user not found", errors.NotFound), errors.SeverityWarning)
+	err = errors.WithOp(errors.Wrap(err, "get user"), "GetUser")
+
+	event := errsentry.Event(err)
+	fmt.Println(event.Level, event.Message, event.Tags["code"], event.Tags["op"])
+	frames := event.Exception[0].Stacktrace.Frames
+	fmt.Println(len(frames), frames[len(frames)-1].Function)
+
+	// Output:
+	// warning get user: user not found NOT_FOUND GetUser
+	// 2 github.com/bzon/errors/errsentry_test.ExampleEvent
+}
+
+func ExampleEvent_inApp() {
+	errors.Configure(errors.Options{AppPackages: []string{"github.com/bzon/errors/errsentry"}})
+	defer errors.Configure(errors.Options{})
+
+	err := errors.WithStack(fmt.Errorf("a"))
+	for _, frame := range errsentry.Event(err).Exception[0].Stacktrace.Frames {
+		if frame.Function == "main.main" || frame.Function == "github.com/bzon/errors/errsentry_test.ExampleEvent_inApp" {
+			fmt.Println(frame.Function, frame.InApp)
+		} else if frame.InApp {
+			fmt.Println("unexpected in app frame", frame.Function)
+		}
+	}
+
+	// Output:
+	// main.main true
+	// github.com/bzon/errors/errsentry_test.ExampleEvent_inApp true
+}
+
+func ExampleReporter() {
+	var sent []*sentry.Event
+	client, _ := sentry.NewClient(sentry.ClientOptions{
+		BeforeSend: func(event *sentry.Event, hint *sentry.EventHint) *sentry.Event {
+			sent = append(sent, event)
+			// Do not send the event to Sentry.
+			return nil
+		},
+	})
+	r := errsentry.NewReporter(sentry.NewHub(client, sentry.NewScope()))
+
+	err := r.Report(context.Background(), []error{errors.New("a"), errors.New("b")})
+	fmt.Println(err, len(sent))
+
+	// Output:
+	// <nil> 2
+}
+
+func Example_extractStacktrace() {
+	err := errors.Wrap(errors.New("user not found"), "get user")
+
+	// The stack traces of the errors are the ones of github.com/pkg/errors, that sentry-go extracts.
+	st := sentry.ExtractStacktrace(err)
+	frames := st.Frames
+	fmt.Println(len(frames), frames[len(frames)-1].Function)
+
+	// Output:
+	// 2 Example_extractStacktrace
+}
@@ -0,0 +1,136 @@
+// Package errtest provides assertions and a fake span to unit test the error handling of applications
+// that use github.com/bzon/errors.
+//
+//	func TestGetUser(t *testing.T) {
+//		_, err := svc.GetUser(ctx, 1)
+//		errtest.HasCode(t, err, errors.NotFound)
+//		errtest.SourceLocationWithin(t, err, "service.go")
+//	}
+//
+// SpanRecorder is a fake span, to verify the annotations of the errors without a tracer.
+package errtest
+
+import (
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bzon/errors"
+)
+
+// HasCode reports whether the code of err is code, and fails t otherwise, see errors.CodeOf.
+func HasCode(t testing.TB, err error, code errors.Code) bool {
+	t.Helper()
+	if got := errors.CodeOf(err); got != code {
+		t.Errorf("code of %v = %v, want %v", err, got, code)
+		return false
+	}
+	return true
+}
+
+// HasTraceContext reports whether the outermost ErrorTracer in the chain of err has a trace context,
+// and fails t otherwise.
+func HasTraceContext(t testing.TB, err error) bool {
+	t.Helper()
+	e, ok := errors.TracerOf(err)
+	if !ok {
+		t.Errorf("%v is not an ErrorTracer", err)
+		return false
+	}
+	if tc := e.TraceContext(); tc.TraceID == "" || tc.SpanID == "" {
+		t.Errorf("%v has no trace context", err)
+		return false
+	}
+	return true
+}
+
+// SourceLocationWithin reports whether the source location of the outermost ErrorTracer in the chain of err
+// is within file, and fails t otherwise.
+// The file is matched against the trailing path elements of the source location,
+// e.g. service.go or user/service.go.
+func SourceLocationWithin(t testing.TB, err error, file string) bool {
+	t.Helper()
+	e, ok := errors.TracerOf(err)
+	if !ok {
+		t.Errorf("%v is not an ErrorTracer", err)
+		return false
+	}
+	src := e.SourceLocation()
+	if got := path.Clean(src.File); got != file && !strings.HasSuffix(got, "/"+file) {
+		t.Errorf("source location of %v = %s:%d, want within %s", err, src.File, src.Line, file)
+		return false
+	}
+	return true
+}
+
+// Annotation is an error annotated on a SpanRecorder.
+type Annotation struct {
+	Message        string
+	SourceLocation errors.SourceLocation
+	Code           errors.Code
+	// Fields are the span attributes of the fields of the error, see errors.FieldsAnnotator.
+	Fields []errors.Field
+}
+
+// SpanRecorder is a fake span that records the errors annotated on it.
+// It is an errors.FieldsAnnotator and an errors.LinkAnnotator, with a fixed trace context.
+type SpanRecorder struct {
+	mu          sync.Mutex
+	annotations []Annotation
+	links       []errors.TraceContext
+}
+
+// NewSpanRecorder returns an empty SpanRecorder.
+func NewSpanRecorder() *SpanRecorder {
+	return &SpanRecorder{}
+}
+
+// Option annotates the error on r, see errors.WithSpanAnnotator.
+func (r *SpanRecorder) Option() errors.Option {
+	return errors.WithSpanAnnotator(r)
+}
+
+// TraceContext returns the fixed trace context of r.
+func (r *SpanRecorder) TraceContext() errors.TraceContext {
+	return errors.TraceContext{
+		TraceID:    "0af7651916cd43dd8448eb211c80319c",
+		SpanID:     "b7ad6b7169203331",
+		TraceFlags: 1,
+	}
+}
+
+// AnnotateError records the annotation.
+func (r *SpanRecorder) AnnotateError(msg string, src errors.SourceLocation, code errors.Code) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.annotations = append(r.annotations, Annotation{Message: msg, SourceLocation: src, Code: code})
+}
+
+// AnnotateErrorFields records the annotation with the fields.
+func (r *SpanRecorder) AnnotateErrorFields(msg string, src errors.SourceLocation, code errors.Code, fields []errors.Field) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.annotations = append(r.annotations, Annotation{Message: msg, SourceLocation: src, Code: code, Fields: fields})
+}
+
+// Annotations returns the annotations recorded so far, in order.
+func (r *SpanRecorder) Annotations() []Annotation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Annotation(nil), r.annotations...)
+}
+
+// AddLink records the link.
+func (r *SpanRecorder) AddLink(tc errors.TraceContext) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.links = append(r.links, tc)
+}
+
+// Links returns the links recorded so far, in order.
+func (r *SpanRecorder) Links() []errors.TraceContext {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]errors.TraceContext(nil), r.links...)
+}
@@ -0,0 +1,53 @@
+package errtest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/errtest"
+)
+
+// recorder records the failures of the assertions.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestAssertions(t *testing.T) {
+	span := errtest.NewSpanRecorder()
+	err := errors.NewO("user not found", errors.NotFound, span.Option())
+
+	r := &recorder{}
+	if !errtest.HasCode(r, err, errors.NotFound) ||
+		!errtest.HasTraceContext(r, err) ||
+		!errtest.SourceLocationWithin(r, err, "errtest/errtest_test.go") {
+		t.Errorf("failures = %q, want none", r.failures)
+	}
+
+	r = &recorder{}
+	errtest.HasCode(r, err, errors.Internal)
+	errtest.HasTraceContext(r, errors.New("a"))
+	errtest.SourceLocationWithin(r, err, "service.go")
+	if len(r.failures) != 3 {
+		t.Errorf("failures = %q, want 3", r.failures)
+	}
+}
+
+func ExampleSpanRecorder() {
+	span := errtest.NewSpanRecorder()
+	_ = errors.NewO("user not found", errors.NotFound, span.Option())
+
+	for _, a := range span.Annotations() {
+		fmt.Println(a.Message, a.Code, a.SourceLocation.Function)
+	}
+
+	// Output:
+	// user not found NOT_FOUND github.com/bzon/errors/errtest_test.ExampleSpanRecorder
+}
@@ -0,0 +1,86 @@
+// Package errzap logs errors with go.uber.org/zap.
+// The errors are logged with the Stackdriver fields, like errors.LogFields does for go-kit loggers.
+package errzap
+
+import (
+	"github.com/bzon/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Fields returns errors.LogFields of err as zap fields.
+// It returns nil when err is not an errors.ErrorTracer.
+//
+//	logger.Error(err.Error(), errzap.Fields(err)...)
+func Fields(err error) []zap.Field {
+	kv := errors.LogFields(err)
+	if kv == nil {
+		return nil
+	}
+	fields := make([]zap.Field, 0, len(kv)/2)
+	for i := 0; i < len(kv); i += 2 {
+		fields = append(fields, field(kv[i].(string), kv[i+1]))
+	}
+	return fields
+}
+
+// Error returns a field that logs err as an object with the "error" key, see Object.
+//
+//	logger.Error("failed", errzap.Error(err))
+func Error(err error) zap.Field {
+	if err == nil {
+		return zap.Skip()
+	}
+	return zap.Object("error", Object(err))
+}
+
+// Object returns a zapcore.ObjectMarshaler that logs the message of err, scrubbed by the redactors,
+// and errors.LogFields of err.
+func Object(err error) zapcore.ObjectMarshaler {
+	return object{err: err}
+}
+
+type object struct {
+	err error
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+func (o object) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("message", errors.RedactedMessage(o.err))
+	kv := errors.LogFields(o.err)
+	for i := 0; i < len(kv); i += 2 {
+		field(kv[i].(string), kv[i+1]).AddTo(enc)
+	}
+	return nil
+}
+
+// field returns the zap field of a key value pair of errors.LogFields.
+func field(key string, value interface{}) zap.Field {
+	switch v := value.(type) {
+	case string:
+		return zap.String(key, v)
+	case errors.SourceLocation:
+		return zap.Object(key, sourceLocation(v))
+	default:
+		return zap.Any(key, v)
+	}
+}
+
+type sourceLocation errors.SourceLocation
+
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+func (s sourceLocation) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("function", s.Function)
+	enc.AddString("file", s.File)
+	enc.AddInt("line", s.Line)
+	if s.Version != "" {
+		enc.AddString("version", s.Version)
+	}
+	if s.Commit != "" {
+		enc.AddString("commit", s.Commit)
+	}
+	if s.Branch != "" {
+		enc.AddString("branch", s.Branch)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package errzap_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/errzap"
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func ExampleFields() {
+	_, span := trace.StartSpan(context.Background(), "foo")
+	defer span.End()
+
+	err := errors.NewT(span, "a")
+	for _, f := range errzap.Fields(err) {
+		fmt.Println(f.Key)
+	}
+
+	// Output:
+	// severity
+	// logging.googleapis.com/sourceLocation
+	// logging.googleapis.com/trace
+	// logging.googleapis.com/spanId
+	// logging.googleapis.com/trace_sampled
+	// fingerprint
+	// errorId
+}
+
+func ExampleError() {
+	core, logs := observer.New(zap.ErrorLevel)
+	logger := zap.New(core)
+
+	err := errors.WithSeverity(errors.New("a"), errors.SeverityCritical)
+	logger.Error("failed", errzap.Error(err))
+
+	e := logs.All()[0].ContextMap()["error"].(map[string]interface{})
+	fmt.Println(e["message"], e["severity"])
+	fmt.Println(e["logging.googleapis.com/sourceLocation"].(map[string]interface{})["function"])
+
+	// Output:
+	// a CRITICAL
+	// github.com/bzon/errors/errzap_test.ExampleError
+}
@@ -0,0 +1,50 @@
+// Package errzerolog logs errors with github.com/rs/zerolog.
+// The errors are logged with the Stackdriver fields, like errors.LogFields does for go-kit loggers,
+// and with their code and fields.
+package errzerolog
+
+import (
+	"github.com/bzon/errors"
+	"github.com/rs/zerolog"
+)
+
+// Marshal writes errors.LogFields of err, and the code and the fields of err, to e.
+// The fields are scrubbed by the redactors, see errors.AddRedactor.
+// It writes nothing when err is not an errors.ErrorTracer.
+//
+//	errzerolog.Marshal(logger.Error(), err).Msg(err.Error())
+func Marshal(e *zerolog.Event, err error) *zerolog.Event {
+	return e.EmbedObject(Object(err))
+}
+
+// Object returns a zerolog.LogObjectMarshaler that writes err like Marshal.
+//
+//	logger.Error().Object("error", errzerolog.Object(err)).Msg("failed")
+func Object(err error) zerolog.LogObjectMarshaler {
+	return object{err: err}
+}
+
+type object struct {
+	err error
+}
+
+// MarshalZerologObject implements zerolog.LogObjectMarshaler.
+func (o object) MarshalZerologObject(e *zerolog.Event) {
+	kv := errors.LogFields(o.err)
+	if kv == nil {
+		return
+	}
+	for i := 0; i < len(kv); i += 2 {
+		key := kv[i].(string)
+		switch v := kv[i+1].(type) {
+		case string:
+			e.Str(key, v)
+		default:
+			e.Interface(key, v)
+		}
+	}
+	e.Str("code", errors.CodeOf(o.err).String())
+	if fields := errors.RedactedFields(o.err); len(fields) > 0 {
+		e.Dict("fields", zerolog.Dict().Fields(map[string]interface{}(fields)))
+	}
+}
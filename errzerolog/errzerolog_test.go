@@ -0,0 +1,38 @@
+package errzerolog_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/errzerolog"
+	"github.com/rs/zerolog"
+)
+
+func ExampleMarshal() {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+
+	err := errors.NewO("user not found", errors.NotFound, errors.WithFields(errors.Fields{"user": 1}))
+	errzerolog.Marshal(logger.Error(), err).Msg(err.Error())
+
+	var entry map[string]interface{}
+	_ = json.Unmarshal(buf.Bytes(), &entry)
+	fmt.Println(entry["message"], entry["severity"], entry["code"], entry["fields"])
+	fmt.Println(entry["logging.googleapis.com/sourceLocation"].(map[string]interface{})["function"])
+
+	// Output:
+	// user not found ERROR NOT_FOUND map[user:1]
+	// github.com/bzon/errors/errzerolog_test.ExampleMarshal
+}
+
+func ExampleObject() {
+	logger := zerolog.New(os.Stdout)
+
+	logger.Error().Object("error", errzerolog.Object(fmt.Errorf("a"))).Msg("failed")
+
+	// Output:
+	// {"level":"error","error":{},"message":"failed"}
+}
@@ -1,3 +1,5 @@
+//go:build !noopencensus
+
 package main
 
 import (
@@ -8,6 +10,7 @@ import (
 
 	"contrib.go.opencensus.io/exporter/jaeger"
 	"github.com/bzon/errors"
+	"github.com/bzon/errors/errkit"
 	"github.com/go-kit/kit/log"
 	"go.opencensus.io/trace"
 )
@@ -34,13 +37,8 @@ func main() {
 
 	for {
 		workerr := work(context.Background(), logger)
-		if ec, ok := workerr.(errors.ErrorTracer); ok {
-			logger.Log(
-				"message", ec.Error(),
-				"logging.googleapis.com/spanId", ec.TraceContext().SpanID,
-				"logging.googleapis.com/trace", ec.TraceContext().TraceID,
-				"logging.googleapis.com/sourceLocation", ec.SourceLocation(),
-			)
+		if workerr != nil {
+			logger.Log(errkit.Keyvals(workerr)...)
 		}
 		time.Sleep(3 * time.Second)
 	}
@@ -0,0 +1,38 @@
+// Package fibererr handles the errors of Fiber handlers.
+// The errors are annotated on the span of the user context of the request, logged with the Stackdriver fields,
+// and written as application/problem+json responses with the HTTP status of the error.
+//
+//	app := fiber.New(fiber.Config{ErrorHandler: fibererr.ErrorHandler})
+package fibererr
+
+import (
+	"encoding/json"
+	"log/slog"
+
+	"github.com/bzon/errors"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ErrorHandler is a fiber.ErrorHandler.
+// The error is wrapped with the request method and path and annotated on the span of the user context.
+// It keeps the source location where it occurred, see errors.AtOrigin.
+// The status of a *fiber.Error is kept, e.g. 404 for unknown routes.
+// It is logged with the Stackdriver fields to the slog default logger,
+// and written as an application/problem+json response, see errors.ToProblem, with the path of the request as instance.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	ctx := c.UserContext()
+	if fe, ok := errors.AsType[*fiber.Error](err); ok && errors.CodeOf(err) == errors.Unknown {
+		err = errors.WithHTTPStatus(err, fe.Code)
+	}
+	err = errors.WrapO(err, c.Method()+" "+c.Path(), errors.WithContext(ctx), errors.AtOrigin())
+	slog.Default().LogAttrs(ctx, slog.LevelError, errors.RedactedMessage(err), errors.SlogAttrs(err)...)
+	p := errors.ToProblem(err)
+	p.Instance = c.Path()
+	body, jerr := json.Marshal(p)
+	if jerr != nil {
+		return jerr
+	}
+	c.Set(fiber.HeaderContentType, errors.ProblemContentType)
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	return c.Status(p.Status).Send(body)
+}
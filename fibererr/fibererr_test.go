@@ -0,0 +1,38 @@
+package fibererr_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/fibererr"
+	"github.com/gofiber/fiber/v2"
+)
+
+func ExampleErrorHandler() {
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	app := fiber.New(fiber.Config{ErrorHandler: fibererr.ErrorHandler})
+	app.Get("/users/:id", func(c *fiber.Ctx) error {
+		return errors.WithCode(errors.New("user not found"), errors.NotFound)
+	})
+
+	for _, path := range []string{"/users/1", "/unknown"} {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		p := errors.ProblemDetails{}
+		_ = json.NewDecoder(resp.Body).Decode(&p)
+		fmt.Println(resp.StatusCode, resp.Header.Get("Content-Type"), p.Detail, p.Instance)
+	}
+
+	// Output:
+	// 404 application/problem+json Not Found /users/1
+	// 404 application/problem+json Not Found /unknown
+}
@@ -0,0 +1,116 @@
+package errors
+
+import (
+	"sort"
+	"time"
+)
+
+// DefaultFieldAttributePrefix is the default prefix of the span attributes of the fields,
+// see Options.FieldAttributePrefix.
+const DefaultFieldAttributePrefix = "error.field."
+
+// Fields are key value pairs that describe an error.
+type Fields map[string]interface{}
+
+// merge returns a new Fields with the fields of f and other, or nil if there are none.
+// The fields of other take precedence.
+// The result never shares the map of f or other, that the caller may modify.
+func (f Fields) merge(other Fields) Fields {
+	if len(f) == 0 && len(other) == 0 {
+		return nil
+	}
+	merged := make(Fields, len(f)+len(other))
+	for k, v := range f {
+		merged[k] = v
+	}
+	for k, v := range other {
+		merged[k] = v
+	}
+	return merged
+}
+
+// FieldsOf returns the fields of every error in the chain of err,
+// and Options.DefaultFields when the chain has an ErrorTracer.
+// The fields of outer errors take precedence, and the default fields come last.
+// It returns nil when there are none.
+func FieldsOf(err error) Fields {
+	var fields Fields
+	traced := false
+	visit(err, func(e *errorContext) bool {
+		// e is inner to the errors visited so far.
+		fields = e.fields.merge(fields)
+		traced = true
+		return false
+	})
+	if defaults := config().DefaultFields; traced && len(defaults) > 0 {
+		fields = defaults.merge(fields)
+	}
+	return fields
+}
+
+// Field is a typed key value pair of an error, created by Str, Int, Bool, Dur or Time.
+// Its value is a string, an int64, a bool, a time.Duration or a time.Time,
+// that map onto the attribute types of the tracing libraries without reflection.
+// It is an Option that adds the field to the error, like WithFields.
+//
+//	err := errors.NewO("user not found", errors.NotFound, errors.Str("user", id), errors.Int("attempt", n))
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+func (f Field) apply(s *settings) {
+	s.fields = s.fields.merge(Fields{f.Key: f.Value})
+}
+
+// Str returns a string field.
+func Str(key, value string) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int returns an int64 field.
+func Int(key string, value int64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Bool returns a bool field.
+func Bool(key string, value bool) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Dur returns a time.Duration field.
+func Dur(key string, value time.Duration) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Time returns a time.Time field.
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, Value: value}
+}
+
+// FieldAttributes returns the redacted fields of err, see RedactedFields, sorted by key,
+// with the keys prefixed by Options.FieldAttributePrefix, as a FieldsAnnotator records them.
+// It is meant to export the fields of the errors as the attributes of other telemetry signals, e.g. logs.
+func FieldAttributes(err error) []Field {
+	return fieldAttributes(err, config().FieldAttributePrefix)
+}
+
+// fieldAttributes returns the redacted fields of err, sorted by key, with the keys prefixed by prefix,
+// or DefaultFieldAttributePrefix if prefix is empty.
+func fieldAttributes(err error, prefix string) []Field {
+	fields := RedactedFields(err)
+	if len(fields) == 0 {
+		return nil
+	}
+	if prefix == "" {
+		prefix = DefaultFieldAttributePrefix
+	}
+	attrs := make([]Field, 0, len(fields))
+	for k, v := range fields {
+		attrs = append(attrs, Field{Key: prefix + k, Value: v})
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].Key < attrs[j].Key
+	})
+	return attrs
+}
@@ -0,0 +1,46 @@
+package errors_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/errtest"
+)
+
+func ExampleFieldsOf() {
+	err := errors.NewO("a", errors.WithFields(errors.Fields{"user": "jb", "id": 1}))
+	err = errors.WrapO(err, "b", errors.WithFields(errors.Fields{"id": 2}))
+	fmt.Println(errors.FieldsOf(err))
+
+	// Output:
+	// map[id:2 user:jb]
+}
+
+func ExampleField() {
+	err := errors.NewO("a",
+		errors.Str("user", "jb"),
+		errors.Int("attempt", 3),
+		errors.Bool("cached", false),
+		errors.Dur("elapsed", 1500*time.Millisecond),
+	)
+	fmt.Println(errors.FieldsOf(err))
+
+	// Output:
+	// map[attempt:3 cached:false elapsed:1.5s user:jb]
+}
+
+func ExampleFieldsAnnotator() {
+	span := errtest.NewSpanRecorder()
+	_ = errors.NewO("user not found", errors.NotFound, errors.Str("user", "jb"), errors.Int("attempt", 3), span.Option())
+
+	for _, a := range span.Annotations() {
+		for _, f := range a.Fields {
+			fmt.Println(f.Key, f.Value)
+		}
+	}
+
+	// Output:
+	// error.field.attempt 3
+	// error.field.user jb
+}
@@ -0,0 +1,67 @@
+package errors
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// The 64-bit FNV-1a parameters, see hash/fnv.
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// Fingerprint returns a stable ID of the kind of err, to group identical errors across hosts.
+// It is a hash of the root error type, the root message template, and the function and file
+// where the innermost ErrorTracer of the chain of err was created.
+// The root message template is the format of Errorf, so that errors differing only in
+// their arguments have the same fingerprint.
+// The errors read by FromHeaders keep the fingerprint of the original error.
+// It returns an empty string for a nil error.
+func Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+	var origin *errorContext
+	root := err
+	var cycle chainCycle
+	for {
+		if e, ok := root.(*errorContext); ok {
+			origin = e
+		}
+		next := unwrapFirst(root)
+		if next == nil || cycle.seen(next) {
+			break
+		}
+		root = next
+	}
+
+	if origin != nil && origin.remote != nil && origin.remote.fingerprint != "" {
+		return origin.remote.fingerprint
+	}
+
+	template := root.Error()
+	if origin != nil && origin.err == root && origin.format != "" {
+		template = origin.format
+	}
+
+	h := fnvAdd(fnvOffset64, reflect.TypeOf(root).String())
+	h = fnvAdd(h, template)
+	if origin != nil {
+		src := origin.SourceLocation()
+		h = fnvAdd(h, src.Function)
+		h = fnvAdd(h, src.File)
+	}
+	return strconv.FormatUint(h, 16)
+}
+
+// fnvAdd adds s and a newline to the 64-bit FNV-1a hash h, without the allocations of hash/fnv.
+func fnvAdd(h uint64, s string) uint64 {
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnvPrime64
+	}
+	h ^= '\n'
+	h *= fnvPrime64
+	return h
+}
@@ -0,0 +1,23 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+)
+
+func findUser(id int) error {
+	return errors.Errorf("user %d not found", id)
+}
+
+func ExampleFingerprint() {
+	a := errors.Wrap(findUser(1), "get user")
+	b := errors.Wrap(findUser(2), "delete user")
+	c := errors.Errorf("user %d not found", 1)
+	fmt.Println(errors.Fingerprint(a) == errors.Fingerprint(b))
+	fmt.Println(errors.Fingerprint(a) == errors.Fingerprint(c))
+
+	// Output:
+	// true
+	// false
+}
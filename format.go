@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Formatter writes the %+v format of the errors, see SetFormatter.
+type Formatter interface {
+	// Format writes the message of err followed by its frames to w.
+	Format(w io.Writer, err ErrorTracer)
+}
+
+// formatterValue holds a Formatter, as an atomic.Value only stores values of the same concrete type.
+type formatterValue struct {
+	Formatter
+}
+
+var formatter atomic.Value
+
+// SetFormatter sets the package-global Formatter of the %+v format, e.g. PrettyFormatter in development.
+// A nil f restores DefaultFormatter.
+func SetFormatter(f Formatter) {
+	formatter.Store(formatterValue{f})
+}
+
+// currentFormatter returns the Formatter set by SetFormatter, or DefaultFormatter.
+func currentFormatter() Formatter {
+	if f, _ := formatter.Load().(formatterValue); f.Formatter != nil {
+		return f.Formatter
+	}
+	return DefaultFormatter{}
+}
+
+// DefaultFormatter is the default Formatter, that formats the frames like github.com/pkg/errors:
+// the function and file:line of every frame of the chain, outermost first,
+// and their source code snippets, see Options.SnippetLines.
+type DefaultFormatter struct{}
+
+// Format implements Formatter.
+func (DefaultFormatter) Format(w io.Writer, err ErrorTracer) {
+	_, _ = io.WriteString(w, err.Error())
+	for _, src := range FramesOf(err) {
+		_, _ = io.WriteString(w, "\n"+src.Function+"\n\t"+src.File+":"+strconv.Itoa(src.Line))
+		if snip := snippet(src); snip != "" {
+			_, _ = io.WriteString(w, "\n\t"+strings.ReplaceAll(strings.TrimSuffix(snip, "\n"), "\n", "\n\t"))
+		}
+	}
+}
+
+// Format formats the error like the errors of github.com/pkg/errors:
+//
+//	%s    the error message
+//	%v    the error message
+//	%q    the quoted error message
+//	%+v   the error message, followed by the frames of the chain as written by the Formatter set by SetFormatter
+func (e *errorContext) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			AnnotateDeferred(e)
+			currentFormatter().Format(s, e)
+			return
+		}
+		_, _ = io.WriteString(s, e.Error())
+	case 's':
+		_, _ = io.WriteString(s, e.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	}
+}
@@ -0,0 +1,26 @@
+package errors_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleErrorTracer_format() {
+	err := errors.Wrap(errors.New("a"), "b")
+
+	fmt.Printf("%v\n", err)
+	fmt.Printf("%q\n", err)
+	lines := strings.Split(fmt.Sprintf("%+v", err), "\n")
+	fmt.Println(lines[0])
+	fmt.Println(lines[1])
+	fmt.Println(len(lines))
+
+	// Output:
+	// b: a
+	// "b: a"
+	// b: a
+	// github.com/bzon/errors_test.ExampleErrorTracer_format
+	// 5
+}
@@ -0,0 +1,46 @@
+// Package ginerr handles the errors of Gin handlers.
+// The errors are annotated on the span of the request, logged with the Stackdriver fields,
+// and written as application/problem+json responses with the HTTP status of the error.
+//
+//	router := gin.New()
+//	router.Use(ginerr.ErrorHandler())
+//	router.GET("/users/:id", func(c *gin.Context) {
+//		_ = c.Error(errors.WithCode(errors.New("user not found"), errors.NotFound))
+//	})
+package ginerr
+
+import (
+	"encoding/json"
+	"log/slog"
+
+	"github.com/bzon/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorHandler returns a middleware that handles the last error added with gin.Context.Error
+// by the next handlers.
+// The error is wrapped with the request method and path and annotated on the span of the request.
+// It keeps the source location where it occurred, see errors.AtOrigin.
+// It is logged with the Stackdriver fields, and the httpRequest of the request, to the slog default logger,
+// and written as an application/problem+json response, see errors.ToProblem,
+// unless the handlers have written a response already.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		last := c.Errors.Last()
+		if last == nil {
+			return
+		}
+		ctx := c.Request.Context()
+		err := errors.WrapO(last.Err, c.Request.Method+" "+c.Request.URL.Path, errors.WithContext(ctx), errors.AtOrigin())
+		err = errors.WithHTTPRequest(err, c.Request)
+		slog.Default().LogAttrs(ctx, slog.LevelError, errors.RedactedMessage(err), errors.SlogAttrs(err)...)
+		if c.Writer.Written() {
+			return
+		}
+		p := errors.ToProblem(err)
+		body, _ := json.Marshal(p)
+		c.Header("X-Content-Type-Options", "nosniff")
+		c.Data(p.Status, errors.ProblemContentType, body)
+	}
+}
@@ -0,0 +1,45 @@
+package ginerr_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/ginerr"
+	"github.com/gin-gonic/gin"
+)
+
+func ExampleErrorHandler() {
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			// Keep the output stable.
+			if a.Key != slog.MessageKey && a.Key != "severity" {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})))
+	gin.SetMode(gin.ReleaseMode)
+
+	router := gin.New()
+	router.Use(ginerr.ErrorHandler())
+	router.GET("/users/:id", func(c *gin.Context) {
+		_ = c.Error(errors.WithCode(errors.New("user not found"), errors.NotFound))
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+	p := errors.ProblemDetails{}
+	_ = json.Unmarshal(w.Body.Bytes(), &p)
+	fmt.Println(w.Code, w.Header().Get("Content-Type"))
+	fmt.Println(p.Title, p.Code, p.Detail, p.Instance)
+
+	// Output:
+	// {"msg":"GET /users/1: user not found","severity":"ERROR"}
+	// 404 application/problem+json
+	// Not Found NOT_FOUND Not Found /users/1
+}
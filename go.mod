@@ -1,13 +1,34 @@
 module github.com/bzon/errors
 
-go 1.14
+go 1.21
 
 require (
 	contrib.go.opencensus.io/exporter/jaeger v0.2.0
 	github.com/go-kit/kit v0.10.0
+	github.com/pkg/errors v0.9.1
+	github.com/rs/zerolog v1.31.0
+	github.com/sirupsen/logrus v1.9.3
+	go.opencensus.io v0.22.3
+	go.uber.org/zap v1.26.0
+	golang.org/x/text v0.17.0
+	golang.org/x/xerrors v0.0.0-20240903120638-7835f813f4da
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20230711160842-782d3b101e98
+	google.golang.org/grpc v1.58.3
+	google.golang.org/protobuf v1.32.0
+)
+
+require (
+	github.com/go-logfmt/logfmt v0.5.1 // indirect
 	github.com/golang/groupcache v0.0.0-20200121045136-8c9f03a8e57e // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/google/go-cmp v0.6.0 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/stretchr/testify v1.9.0 // indirect
 	github.com/uber/jaeger-client-go v2.22.1+incompatible // indirect
-	go.opencensus.io v0.22.3
-	golang.org/x/sync v0.0.0-20200317015054-43a5402ce75a // indirect
+	go.uber.org/multierr v1.10.0 // indirect
+	golang.org/x/net v0.28.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+	golang.org/x/sys v0.23.0 // indirect
 	google.golang.org/api v0.20.0 // indirect
 )
@@ -0,0 +1,82 @@
+// Package gqlerr converts errors to GraphQL errors, for gqlgen based servers.
+// The errors surface their public message, and their code and trace context as extensions,
+// so that clients get sanitized errors they can correlate with the logs and traces of the server.
+//
+//	srv := handler.NewDefaultServer(generated.NewExecutableSchema(cfg))
+//	srv.SetErrorPresenter(gqlerr.Presenter)
+package gqlerr
+
+import (
+	"context"
+
+	"github.com/bzon/errors"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// The extension keys of the GraphQL errors created by this package.
+const (
+	ExtensionCode    = "code"
+	ExtensionTraceID = "traceId"
+	ExtensionSpanID  = "spanId"
+	ExtensionErrorID = "errorId"
+)
+
+// Extensions returns the code of err, see errors.CodeOf, the ID of err, and the outermost trace context
+// in the chain of err, as GraphQL error extensions.
+func Extensions(err error) map[string]interface{} {
+	ext := map[string]interface{}{
+		ExtensionCode: errors.CodeOf(err).String(),
+	}
+	if e, ok := errors.TracerOf(err); ok {
+		ext[ExtensionErrorID] = errors.IDOf(e)
+	}
+	errors.Walk(err, func(err error) bool {
+		e, ok := err.(errors.ErrorTracer)
+		if !ok || e.TraceContext().TraceID == "" {
+			return true
+		}
+		ext[ExtensionTraceID] = e.TraceContext().TraceID
+		ext[ExtensionSpanID] = e.TraceContext().SpanID
+		return false
+	})
+	return ext
+}
+
+// ToGQLError converts err to a GraphQL error with its public message, see errors.PublicMessage,
+// and its Extensions. The message of err is not exposed, unless it is its public message.
+// It returns nil for a nil error.
+func ToGQLError(err error) *gqlerror.Error {
+	if err == nil {
+		return nil
+	}
+	return &gqlerror.Error{
+		Err:        err,
+		Message:    errors.PublicMessage(err),
+		Extensions: Extensions(err),
+	}
+}
+
+// Presenter is a gqlgen error presenter.
+// The errors of resolvers are wrapped with their path and annotated on the span of ctx,
+// and converted with ToGQLError, keeping their path and locations.
+// They keep the source location where they occurred, see errors.AtOrigin.
+// The GraphQL errors of the parsing and validation of queries, that wrap no error, are returned as is.
+func Presenter(ctx context.Context, err error) *gqlerror.Error {
+	m := "graphql"
+	gerr, ok := errors.AsType[*gqlerror.Error](err)
+	if ok {
+		if gerr.Err == nil {
+			return gerr
+		}
+		err = gerr.Err
+		if len(gerr.Path) > 0 {
+			m += " " + gerr.Path.String()
+		}
+	}
+	out := ToGQLError(errors.WrapO(err, m, errors.WithContext(ctx), errors.AtOrigin()))
+	if ok {
+		out.Path = gerr.Path
+		out.Locations = gerr.Locations
+	}
+	return out
+}
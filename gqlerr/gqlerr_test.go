@@ -0,0 +1,30 @@
+package gqlerr_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/gqlerr"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+func ExamplePresenter() {
+	err := errors.NewO("user 1 not found in shard 3", errors.NotFound, errors.WithTraceContext(errors.TraceContext{
+		TraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:  "00f067aa0ba902b7",
+	}))
+	err = errors.WithPublicMessage(err, "User not found.")
+
+	gerr := gqlerr.Presenter(context.Background(), gqlerror.WrapPath(ast.Path{ast.PathName("user")}, err))
+	delete(gerr.Extensions, gqlerr.ExtensionErrorID)
+	b, _ := json.Marshal(gerr)
+	fmt.Println(string(b))
+	fmt.Println(gerr.Err)
+
+	// Output:
+	// {"message":"User not found.","path":["user"],"extensions":{"code":"NOT_FOUND","spanId":"00f067aa0ba902b7","traceId":"4bf92f3577b34da6a3ce929d0e0e4736"}}
+	// graphql user: user 1 not found in shard 3
+}
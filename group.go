@@ -0,0 +1,70 @@
+package errors
+
+import (
+	"context"
+	"sync"
+)
+
+// Group is a collection of goroutines working on subtasks of a common task.
+// It mirrors golang.org/x/sync/errgroup.Group, but the errors and recovered panics
+// of the goroutines are traced with the source location of Go and the span of the group.
+// A zero Group is valid and does not cancel on error.
+type Group struct {
+	cancel    func()
+	annotator SpanAnnotator
+
+	wg sync.WaitGroup
+
+	errOnce sync.Once
+	err     error
+}
+
+// NewGroup returns a new Group and an associated Context derived from ctx.
+// The derived Context is canceled the first time a function passed to Go returns an error or panics,
+// or the first time Wait returns, whichever occurs first.
+// The errors are annotated on the span of ctx, if any.
+func NewGroup(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{cancel: cancel, annotator: contextSpan(ctx)}, ctx
+}
+
+// Wait blocks until all function calls from the Go method have returned,
+// then returns the first non-nil error (if any) from them.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	return g.err
+}
+
+// Go calls the given function in a new goroutine.
+// The first call to return a non-nil error cancels the group; its error will be returned by Wait.
+// The error is wrapped with the source location of the call to Go, a panic is recovered as an error.
+func (g *Group) Go(f func() error) {
+	src := captureLocation(wrappedFunctionCallDepth)
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := g.call(f, src); err != nil {
+			g.errOnce.Do(func() {
+				g.err = err
+				if g.cancel != nil {
+					g.cancel()
+				}
+			})
+		}
+	}()
+}
+
+func (g *Group) call(f func() error, src location) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = recovered(v, g.annotator)
+		}
+	}()
+	if err := f(); err != nil {
+		return created(newErrorContext(err, src), g.annotator)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bzon/errors"
+	"go.opencensus.io/trace"
+)
+
+func ExampleGroup() {
+	ctx, span := trace.StartSpan(context.Background(), "foo")
+	defer span.End()
+
+	g, ctx := errors.NewGroup(ctx)
+	g.Go(func() error {
+		return errSentinel
+	})
+	err := g.Wait()
+	fmt.Println(err)
+	fmt.Println(ctx.Err())
+
+	e := err.(errors.ErrorTracer)
+	fmt.Println(e.SourceLocation().Function)
+	fmt.Println(e.TraceContext().SpanID == span.SpanContext().SpanID.String())
+
+	// Output:
+	// sentinel error
+	// context canceled
+	// github.com/bzon/errors_test.ExampleGroup
+	// true
+}
+
+func ExampleGroup_panic() {
+	var g errors.Group
+	g.Go(func() error {
+		doPanic()
+		return nil
+	})
+	err := g.Wait()
+	fmt.Println(err)
+
+	// Output:
+	// panic: boom
+}
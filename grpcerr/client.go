@@ -0,0 +1,147 @@
+package grpcerr
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/errorspb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryClientInterceptor returns a unary client interceptor that converts the status errors
+// of calls back into ErrorTracer errors, see FromStatus.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return FromError(invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
+
+// StreamClientInterceptor is the stream client interceptor counterpart of UnaryClientInterceptor.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, FromError(err)
+		}
+		return &clientStream{cs}, nil
+	}
+}
+
+type clientStream struct {
+	grpc.ClientStream
+}
+
+func (s *clientStream) SendMsg(m interface{}) error {
+	return FromError(s.ClientStream.SendMsg(m))
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == io.EOF {
+		return err
+	}
+	return FromError(err)
+}
+
+// FromError converts a status error to an ErrorTracer error, see FromStatus.
+// The status error is kept in the chain of the error, so that status.FromError and status.Code still work.
+// Other errors are returned as is.
+func FromError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(interface{ GRPCStatus() *status.Status }); !ok {
+		return err
+	}
+	return FromStatusWithCause(status.Convert(err), err)
+}
+
+// FromStatus converts a status to an ErrorTracer error with its code and message.
+// The source location, trace context, ID, stack and public message are restored from the
+// google.rpc.ErrorInfo, google.rpc.DebugInfo and google.rpc.LocalizedMessage details
+// of statuses created by ToStatus, and the other details are kept as the details of the error, see errors.Details.
+// The error of s is the cause of the error, so that status.FromError and status.Code still work.
+// It returns nil for a nil or OK status.
+func FromStatus(s *status.Status) error {
+	if s == nil {
+		return nil
+	}
+	return FromStatusWithCause(s, s.Err())
+}
+
+// FromStatusWithCause is FromStatus, with cause as the cause of the error instead of the error of s,
+// e.g. the error of another protocol that s was converted from, see errors.FromProtoWithCause.
+func FromStatusWithCause(s *status.Status, cause error) error {
+	if s == nil || s.Err() == nil {
+		return nil
+	}
+	p := &errorspb.Error{
+		Message: s.Message(),
+		Traced:  true,
+		Code:    int32(s.Code()),
+	}
+	var details []interface{}
+	for _, detail := range s.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if d.Domain != Domain {
+				continue
+			}
+			if fn := d.Metadata[MetadataFunction]; fn != "" {
+				line, _ := strconv.ParseInt(d.Metadata[MetadataLine], 10, 64)
+				p.SourceLocation = &errorspb.SourceLocation{
+					Function: fn,
+					File:     d.Metadata[MetadataFile],
+					Line:     line,
+				}
+			}
+			p.TraceContext = &errorspb.TraceContext{
+				TraceId: d.Metadata[MetadataTrace],
+				SpanId:  d.Metadata[MetadataSpanID],
+			}
+			p.Id = d.Metadata[MetadataErrorID]
+		case *errdetails.DebugInfo:
+			for _, entry := range d.StackEntries {
+				p.Stack = append(p.Stack, parseStackEntry(entry))
+			}
+		case *errdetails.LocalizedMessage:
+			p.PublicMessage = d.Message
+		case error:
+			// The type of the detail is not linked in the binary.
+		default:
+			details = append(details, d)
+		}
+	}
+	err := errors.FromProtoWithCause(p, cause)
+	for _, d := range details {
+		err = errors.WithDetail(err, d)
+	}
+	return err
+}
+
+// FromRPCStatus converts a google.rpc.Status to an ErrorTracer error, see FromStatus.
+func FromRPCStatus(s *spb.Status) error {
+	return FromStatus(status.FromProto(s))
+}
+
+// parseStackEntry parses a google.rpc.DebugInfo stack entry created by ToStatus.
+func parseStackEntry(entry string) *errorspb.SourceLocation {
+	src := &errorspb.SourceLocation{}
+	file := entry
+	// Function names have no spaces, unlike file paths.
+	if i := strings.Index(entry, " "); i >= 0 {
+		src.Function, file = entry[:i], entry[i+1:]
+	}
+	if i := strings.LastIndex(file, ":"); i >= 0 {
+		src.Line, _ = strconv.ParseInt(file[i+1:], 10, 64)
+		file = file[:i]
+	}
+	src.File = file
+	return src
+}
@@ -0,0 +1,66 @@
+package grpcerr_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/grpcerr"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+func ExampleUnaryClientInterceptor() {
+	// The server side.
+	_, span := trace.StartSpan(context.Background(), "server")
+	defer span.End()
+	serr := errors.NewO("select user 1: no rows", errors.NotFound, errors.WithSpan(span))
+	serr = errors.WithPublicMessage(serr, "user not found")
+
+	interceptor := grpcerr.UnaryClientInterceptor()
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return grpcerr.ToStatus(serr).Err()
+	}
+
+	err := interceptor(context.Background(), "/users.Users/Get", nil, nil, nil, invoker)
+	fmt.Println(err)
+	fmt.Println(errors.CodeOf(err))
+	e := err.(errors.ErrorTracer)
+	fmt.Println(errors.IDOf(e) == errors.IDOf(serr))
+	fmt.Println(e.TraceContext().SpanID == span.SpanContext().SpanID.String())
+	// The status error is kept in the chain.
+	fmt.Println(status.Code(err))
+
+	// Output:
+	// user not found
+	// NOT_FOUND
+	// true
+	// true
+	// NotFound
+}
+
+func ExampleFromRPCStatus() {
+	// The server sends its source locations and stacks to trusted clients.
+	grpcerr.SendDebugInfo = true
+	defer func() { grpcerr.SendDebugInfo = false }()
+
+	serr := errors.WithPublicMessage(errors.Errorf("select user %d: no rows", 1), "user not found")
+	serr = errors.WithCode(serr, errors.NotFound)
+
+	// The google.rpc.Status sent by the server.
+	s := grpcerr.ToRPCStatus(serr)
+	fmt.Println(s.Message)
+
+	err := grpcerr.FromRPCStatus(s)
+	fmt.Println(errors.CodeOf(err), errors.PublicMessage(err))
+	e := err.(errors.ErrorTracer)
+	fmt.Println(errors.IDOf(e) == errors.IDOf(serr))
+	fmt.Println(errors.StackOf(e)[0].Function, errors.StackOf(e)[0].Line == e.SourceLocation().Line)
+
+	// Output:
+	// user not found
+	// NOT_FOUND user not found
+	// true
+	// github.com/bzon/errors/grpcerr_test.ExampleFromRPCStatus true
+}
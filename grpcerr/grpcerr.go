@@ -0,0 +1,223 @@
+// Package grpcerr provides gRPC interceptors that trace the errors of handlers
+// and convert them to gRPC statuses.
+package grpcerr
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/bzon/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/runtime/protoimpl"
+)
+
+// Domain is the google.rpc.ErrorInfo domain of the statuses created by this package.
+const Domain = "github.com/bzon/errors"
+
+// The google.rpc.ErrorInfo metadata keys of the statuses created by this package.
+const (
+	MetadataTrace    = "trace"
+	MetadataSpanID   = "spanId"
+	MetadataFunction = "function"
+	MetadataFile     = "file"
+	MetadataLine     = "line"
+	MetadataErrorID  = "errorId"
+)
+
+// Locale is the locale of the public messages of errors, see errors.WithPublicMessage.
+// It is the locale of the google.rpc.LocalizedMessage details of the statuses created by ToStatus.
+var Locale = "en-US"
+
+// SendDebugInfo adds the internal details of the errors to the statuses created by ToStatus:
+// their source location to the google.rpc.ErrorInfo detail, and their message and stack as a google.rpc.DebugInfo detail.
+// They are meant for trusted clients, e.g. the other services of the same system, and are not sent by default.
+var SendDebugInfo bool
+
+// UnaryServerInterceptor returns a unary server interceptor that wraps the errors of handlers
+// with the method name, annotates them on the span of the incoming context and converts them to statuses.
+// The errors keep the source location where they occurred, see errors.AtOrigin.
+// Use the ocgrpc server handler to start the spans.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			err = errors.WrapO(err, info.FullMethod, errors.WithContext(ctx), errors.AtOrigin())
+			return resp, ToStatus(err).Err()
+		}
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor is the stream server interceptor counterpart of UnaryServerInterceptor.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err != nil {
+			err = errors.WrapO(err, info.FullMethod, errors.WithContext(ss.Context()), errors.AtOrigin())
+			return ToStatus(err).Err()
+		}
+		return nil
+	}
+}
+
+// Code returns the gRPC code of err.
+// It is the code of err, see errors.CodeOf, or the code of a gRPC status in the chain of err.
+func Code(err error) codes.Code {
+	code := errors.CodeOf(err)
+	if code == errors.Unknown {
+		if s, ok := status.FromError(err); ok {
+			return s.Code()
+		}
+	}
+	return codes.Code(code)
+}
+
+// CodeRule infers the code of the gRPC status errors, that implement GRPCStatus() *status.Status,
+// for errors.CodeOf. The codes of the gRPC statuses are the canonical codes.
+// It is not one of the errors.DefaultCodeRules, so that the errors package does not depend on gRPC:
+//
+//	errors.Configure(errors.Options{
+//		CodeRules: append(errors.DefaultCodeRules(), grpcerr.CodeRule),
+//	})
+func CodeRule(err error) (errors.Code, bool) {
+	s, ok := err.(interface{ GRPCStatus() *status.Status })
+	if !ok {
+		return errors.OK, false
+	}
+	return errors.Code(s.GRPCStatus().Code()), true
+}
+
+// ToStatus converts err to a gRPC status with its code and public message, see errors.PublicMessage,
+// so that the internal message of err is never sent to clients.
+// When err has no public message and a gRPC status error is in its chain, e.g. the error of a call to another service,
+// the message and details of that status are kept instead, see baseStatus.
+// The trace context and ID of the outermost ErrorTracer in the chain of err are added as a google.rpc.ErrorInfo detail,
+// its public message as a google.rpc.LocalizedMessage detail in the Locale,
+// and the protocol buffers messages of its details, see errors.WithDetail.
+// With SendDebugInfo, its source location is added to the google.rpc.ErrorInfo detail, and its redacted message
+// and its stack, or else its frames, as a google.rpc.DebugInfo detail.
+// It returns nil for a nil error.
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+	errors.AnnotateDeferred(err)
+	code := Code(err)
+	s := baseStatus(err, code)
+
+	var e errors.ErrorTracer
+	if !errors.As(err, &e) {
+		return s
+	}
+	tc := e.TraceContext()
+	info := &errdetails.ErrorInfo{
+		Reason: errors.Code(code).String(),
+		Domain: Domain,
+		Metadata: map[string]string{
+			MetadataErrorID: errors.IDOf(e),
+		},
+	}
+	if tc.TraceID != "" {
+		info.Metadata[MetadataTrace] = tc.TraceID
+		info.Metadata[MetadataSpanID] = tc.SpanID
+	}
+	details := []protoiface.MessageV1{info}
+	if SendDebugInfo {
+		src := e.SourceLocation()
+		info.Metadata[MetadataFunction] = src.Function
+		info.Metadata[MetadataFile] = src.File
+		info.Metadata[MetadataLine] = strconv.Itoa(src.Line)
+		debug := &errdetails.DebugInfo{
+			Detail: errors.RedactedMessage(err),
+		}
+		stack := errors.StackOf(e)
+		if len(stack) == 0 {
+			stack = errors.FramesOf(e)
+		}
+		for _, frame := range stack {
+			debug.StackEntries = append(debug.StackEntries, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+		}
+		details = append(details, debug)
+	}
+	if errors.HasPublicMessage(err) {
+		details = append(details, &errdetails.LocalizedMessage{
+			Locale:  Locale,
+			Message: errors.PublicMessage(err),
+		})
+	}
+	base := s.Proto()
+	for _, d := range errors.Details(err) {
+		if m, ok := d.(proto.Message); ok && !hasDetail(base, m) {
+			details = append(details, protoimpl.X.ProtoMessageV1Of(m))
+		}
+	}
+	if ds, derr := s.WithDetails(details...); derr == nil {
+		return ds
+	}
+	return s
+}
+
+// baseStatus returns the status that ToStatus adds the details of err to.
+// It is the status of the first gRPC status error in the chain of err, with code, when err has no public message,
+// so that the message and details of the errors of other services are kept as they were sent.
+// The google.rpc.ErrorInfo details of this package, and the google.rpc.DebugInfo and google.rpc.LocalizedMessage details
+// of that status are removed, since they describe the error of the other service.
+// Otherwise, it is a new status with code and the public message of err.
+func baseStatus(err error, code codes.Code) *status.Status {
+	se, ok := errors.AsType[statusError](err)
+	if !ok || errors.HasPublicMessage(err) {
+		return status.New(code, errors.PublicMessage(err))
+	}
+	p := se.GRPCStatus().Proto()
+	if p == nil {
+		return status.New(code, errors.PublicMessage(err))
+	}
+	p.Code = int32(code)
+	details := p.Details[:0]
+	for _, a := range p.Details {
+		var info errdetails.ErrorInfo
+		switch {
+		case a.MessageIs(&info):
+			if a.UnmarshalTo(&info) == nil && info.Domain == Domain {
+				continue
+			}
+		case a.MessageIs(&errdetails.DebugInfo{}), a.MessageIs(&errdetails.LocalizedMessage{}):
+			continue
+		}
+		details = append(details, a)
+	}
+	p.Details = details
+	return status.FromProto(p)
+}
+
+// statusError is a gRPC status error.
+type statusError interface {
+	error
+	GRPCStatus() *status.Status
+}
+
+// hasDetail reports whether the details of p have m, e.g. the details of a status error
+// that were restored as the details of an error by FromStatus.
+func hasDetail(p *spb.Status, m proto.Message) bool {
+	for _, a := range p.GetDetails() {
+		if !a.MessageIs(m) {
+			continue
+		}
+		if d, err := a.UnmarshalNew(); err == nil && proto.Equal(d, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// ToRPCStatus converts err to a google.rpc.Status, see ToStatus.
+func ToRPCStatus(err error) *spb.Status {
+	return ToStatus(err).Proto()
+}
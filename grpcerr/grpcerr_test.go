@@ -0,0 +1,111 @@
+package grpcerr_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/grpcerr"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func ExampleUnaryServerInterceptor() {
+	grpcerr.SendDebugInfo = true
+	defer func() { grpcerr.SendDebugInfo = false }()
+
+	interceptor := grpcerr.UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.WithCode(errors.New("user not found"), errors.NotFound)
+	}
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/users.Users/Get"}, handler)
+	s := status.Convert(err)
+	fmt.Println(s.Code())
+	fmt.Println(s.Message())
+	for _, detail := range s.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			// The source location is the one of the handler, not of the interceptor.
+			fmt.Println(info.Reason, info.Metadata[grpcerr.MetadataFunction])
+		}
+	}
+
+	// Output:
+	// NotFound
+	// Not Found
+	// NOT_FOUND github.com/bzon/errors/grpcerr_test.ExampleUnaryServerInterceptor.func2
+}
+
+func ExampleCode() {
+	fmt.Println(grpcerr.Code(errors.Wrap(status.Error(5, "not found"), "get user")))
+
+	// Output:
+	// NotFound
+}
+
+func ExampleCodeRule() {
+	errors.Configure(errors.Options{
+		CodeRules: append(errors.DefaultCodeRules(), grpcerr.CodeRule),
+	})
+	defer errors.Configure(errors.Options{})
+
+	fmt.Println(errors.CodeOf(errors.Wrap(status.Error(codes.Unavailable, "unavailable"), "get user")))
+
+	// Output:
+	// UNAVAILABLE
+}
+
+func ExampleUnaryServerInterceptor_status() {
+	interceptor := grpcerr.UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		s, err := status.New(codes.InvalidArgument, "email is invalid").WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "email", Description: "must be valid"}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.Err()
+	}
+
+	// The status of the handler is kept, with the google.rpc.ErrorInfo detail added.
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/users.Users/Create"}, handler)
+	s := status.Convert(err)
+	fmt.Println(s.Code())
+	fmt.Println(s.Message())
+	for _, detail := range s.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			fmt.Println(d.FieldViolations[0].Field)
+		case *errdetails.ErrorInfo:
+			fmt.Println(d.Reason)
+		}
+	}
+
+	// Output:
+	// InvalidArgument
+	// email is invalid
+	// email
+	// INVALID_ARGUMENT
+}
+
+func ExampleToStatus() {
+	serr := errors.NewO("invalid user", errors.InvalidArgument)
+	serr = errors.WithDetail(serr, &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "email", Description: "must be valid"}},
+	})
+
+	s := grpcerr.ToStatus(serr)
+	// The internal message is not sent to clients.
+	fmt.Println(s.Message())
+
+	err := grpcerr.FromStatus(s)
+	for _, d := range errors.Details(err) {
+		fmt.Println(d.(*errdetails.BadRequest).FieldViolations[0].Field)
+	}
+
+	// Output:
+	// Bad Request
+	// email
+}
@@ -0,0 +1,65 @@
+package errors
+
+import (
+	"net/http"
+	"time"
+)
+
+// The headers of the error context, see InjectHeaders.
+const (
+	HeaderErrorCode        = "X-Error-Code"
+	HeaderErrorMessage     = "X-Error-Message"
+	HeaderErrorFingerprint = "X-Error-Fingerprint"
+	HeaderErrorID          = "X-Error-Id"
+	HeaderErrorTrace       = "X-Error-Trace"
+)
+
+// InjectHeaders sets the X-Error-* headers of h to the code, public message, fingerprint and ID of err,
+// and to its trace context in the W3C traceparent format, see TraceContext.TraceParent.
+// Reverse proxies and edge services propagate the identity of an error with them, without reading the body,
+// and FromHeaders reads them back.
+// It does nothing for a nil error.
+func InjectHeaders(err error, h http.Header) {
+	if err == nil {
+		return
+	}
+	h.Set(HeaderErrorCode, CodeOf(err).String())
+	h.Set(HeaderErrorMessage, PublicMessage(err))
+	h.Set(HeaderErrorFingerprint, Fingerprint(err))
+	if e, ok := TracerOf(err); ok {
+		h.Set(HeaderErrorID, IDOf(e))
+	}
+	if tp := traceContextOf(err).TraceParent(); tp != "" {
+		h.Set(HeaderErrorTrace, tp)
+	}
+}
+
+// FromHeaders returns the error whose context is set in the X-Error-* headers of h by InjectHeaders.
+// Its message is the public message of the original error, and it keeps its code, fingerprint, ID and trace context.
+// Its source location is unknown.
+// It returns nil when h has no X-Error-Code header.
+func FromHeaders(h http.Header) error {
+	name := h.Get(HeaderErrorCode)
+	if name == "" {
+		return nil
+	}
+	code, ok := ParseCode(name)
+	if !ok {
+		code = Unknown
+	}
+	msg := h.Get(HeaderErrorMessage)
+	e := newContext(errorContext{
+		err:           &remoteError{msg: msg},
+		code:          code,
+		publicMessage: msg,
+		occurredAt:    time.Now(),
+		id:            nextID(),
+	})
+	if id, fp := h.Get(HeaderErrorID), h.Get(HeaderErrorFingerprint); id != "" || fp != "" {
+		e.remote = &remoteContext{id: id, fingerprint: fp}
+	}
+	if sc, err := ParseTraceParent(h.Get(HeaderErrorTrace)); err == nil {
+		e.traceContext = newTraceContext(sc)
+	}
+	return e
+}
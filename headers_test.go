@@ -0,0 +1,31 @@
+package errors_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleInjectHeaders() {
+	err := errors.NewO("user 1 not found", errors.NotFound, errors.WithTraceContext(errors.TraceContext{
+		TraceID:    "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:     "00f067aa0ba902b7",
+		TraceFlags: 1,
+	}))
+	h := http.Header{}
+	errors.InjectHeaders(err, h)
+	fmt.Println(h.Get(errors.HeaderErrorCode), h.Get(errors.HeaderErrorTrace))
+
+	// On the other side of the proxy.
+	perr := errors.FromHeaders(h)
+	fmt.Println(perr, errors.CodeOf(perr))
+	fmt.Println(errors.Fingerprint(perr) == errors.Fingerprint(err))
+	fmt.Println(errors.IDOf(perr) == errors.IDOf(err))
+
+	// Output:
+	// NOT_FOUND 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+	// Not Found NOT_FOUND
+	// true
+	// true
+}
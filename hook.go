@@ -0,0 +1,54 @@
+package errors
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Hook is called with every error created by the constructors of this package,
+// New, Errorf, Wrap, Join, NewO, Recover and their variants, e.g. to count or report errors.
+type Hook func(ErrorTracer)
+
+var (
+	hooks   atomic.Value
+	hooksMu sync.Mutex
+)
+
+// AddHook adds h to the package-global hooks.
+// The hooks are called in the order they are added, synchronously, after the error is annotated on its span.
+// A panic in a hook is recovered and ignored, so that it affects neither the other hooks nor the constructor.
+// Use the NoHooks option to skip the hooks for an error.
+func AddHook(h Hook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	hs, _ := hooks.Load().([]Hook)
+	hooks.Store(append(hs[:len(hs):len(hs)], h))
+}
+
+// ResetHooks removes the package-global hooks.
+func ResetHooks() {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	hooks.Store([]Hook(nil))
+}
+
+// created annotates the new error e on the span of a, see annotate, and calls the hooks with it.
+// With the tracedisabled build tag, it does neither.
+func created(e *errorContext, a SpanAnnotator) error {
+	if traceDisabled {
+		return e
+	}
+	err := annotate(e, a)
+	hs, _ := hooks.Load().([]Hook)
+	for _, h := range hs {
+		callHook(h, e)
+	}
+	return err
+}
+
+func callHook(h Hook, e *errorContext) {
+	defer func() {
+		_ = recover()
+	}()
+	h(e)
+}
@@ -0,0 +1,31 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleAddHook() {
+	counts := map[errors.Code]int{}
+	errors.AddHook(func(e errors.ErrorTracer) {
+		counts[errors.CodeOf(e)]++
+	})
+	errors.AddHook(func(e errors.ErrorTracer) {
+		panic("the other hooks and the caller are not affected")
+	})
+	errors.AddHook(func(e errors.ErrorTracer) {
+		fmt.Println("created:", e, "at", e.SourceLocation().Function)
+	})
+	defer errors.ResetHooks()
+
+	err := errors.NewO("user not found", errors.NotFound)
+	err = errors.Wrap(err, "get user")
+	_ = errors.NewO("cache miss", errors.NoHooks())
+	fmt.Println(counts)
+
+	// Output:
+	// created: user not found at github.com/bzon/errors_test.ExampleAddHook
+	// created: get user: user not found at github.com/bzon/errors_test.ExampleAddHook
+	// map[NOT_FOUND:2]
+}
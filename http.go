@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"net/http"
+)
+
+// httpStatuses maps the canonical codes to HTTP status codes.
+// See https://github.com/googleapis/googleapis/blob/master/google/rpc/code.proto.
+var httpStatuses = map[Code]int{
+	OK:                 http.StatusOK,
+	Canceled:           499, // Client Closed Request
+	Unknown:            http.StatusInternalServerError,
+	InvalidArgument:    http.StatusBadRequest,
+	DeadlineExceeded:   http.StatusGatewayTimeout,
+	NotFound:           http.StatusNotFound,
+	AlreadyExists:      http.StatusConflict,
+	PermissionDenied:   http.StatusForbidden,
+	ResourceExhausted:  http.StatusTooManyRequests,
+	FailedPrecondition: http.StatusBadRequest,
+	Aborted:            http.StatusConflict,
+	OutOfRange:         http.StatusBadRequest,
+	Unimplemented:      http.StatusNotImplemented,
+	Internal:           http.StatusInternalServerError,
+	Unavailable:        http.StatusServiceUnavailable,
+	DataLoss:           http.StatusInternalServerError,
+	Unauthenticated:    http.StatusUnauthorized,
+}
+
+// HTTPStatus returns the HTTP status code of the outermost error in the chain of err that has one.
+// Otherwise the status is derived from the code of err, see CodeOf.
+// It returns 200 for a nil error.
+func HTTPStatus(err error) int {
+	status := 0
+	visit(err, func(e *errorContext) bool {
+		status = e.httpStatus
+		return status != 0
+	})
+	if status != 0 {
+		return status
+	}
+	if status, ok := httpStatuses[CodeOf(err)]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// WithHTTPStatus returns a copy of err that carries the HTTP status code.
+// If err is not created by this package, it is wrapped with the source location of the caller.
+func WithHTTPStatus(err error, status int) error {
+	if err == nil {
+		return nil
+	}
+	e := withContext(err, wrappedFunctionCallDepth)
+	e.httpStatus = status
+	return e
+}
@@ -0,0 +1,29 @@
+package errors_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleHTTPStatus() {
+	fmt.Println(errors.HTTPStatus(nil))
+	fmt.Println(errors.HTTPStatus(errors.New("a")))
+	fmt.Println(errors.HTTPStatus(errors.WithCode(errors.New("a"), errors.InvalidArgument)))
+
+	// Output:
+	// 200
+	// 500
+	// 400
+}
+
+func ExampleWithHTTPStatus() {
+	err := errors.WithCode(errors.New("a"), errors.NotFound)
+	err = errors.WithHTTPStatus(err, http.StatusGone)
+	err = errors.Wrap(err, "b")
+	fmt.Println(errors.HTTPStatus(err))
+
+	// Output:
+	// 410
+}
@@ -0,0 +1,93 @@
+// Package httperr provides HTTP handlers that return errors, for the standard library and compatible routers, e.g. chi.
+// The errors are annotated on the span of the request, logged with the Stackdriver fields,
+// and written as a response with the HTTP status of the error.
+//
+//	mux.Handle("/users/", httperr.Recoverer(httperr.HandlerFunc(getUser)))
+package httperr
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bzon/errors"
+	"go.opencensus.io/plugin/ochttp"
+)
+
+// HandlerFunc is an HTTP handler that returns an error.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ServeHTTP calls f(w, r) and handles its error with Error.
+// A panic in f is recovered and handled as an Internal error, see errors.Recover.
+// The http.ErrAbortHandler panics, that abort the response, are not recovered.
+func (f HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r = r.WithContext(errors.WithRequestStart(r.Context(), time.Now()))
+	defer recoverError(w, r)
+	if err := f(w, r); err != nil {
+		Error(w, r, err)
+	}
+}
+
+// Recoverer recovers the panics of next, and handles them as Internal errors with Error, see errors.Recover.
+// The http.ErrAbortHandler panics, that abort the response, are not recovered.
+func Recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = r.WithContext(errors.WithRequestStart(r.Context(), time.Now()))
+		defer recoverError(w, r)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// recoverError recovers a panic of the handler of r, and handles it as an Internal error with Error.
+// The http.ErrAbortHandler panics are panicked again. It must be deferred.
+func recoverError(w http.ResponseWriter, r *http.Request) {
+	v := recover()
+	if v == nil {
+		return
+	}
+	if v == http.ErrAbortHandler {
+		panic(v)
+	}
+	Error(w, r, errors.Recover(v))
+}
+
+// Middleware starts an OpenCensus span for every request to next, using ochttp.
+// The errors of the HandlerFunc handlers of next are annotated on that span.
+func Middleware(next http.Handler) http.Handler {
+	return &ochttp.Handler{Handler: next}
+}
+
+// Error handles err of the request r.
+// The error is wrapped with the request method and path and annotated on the span of the request.
+// It keeps the source location of the ErrorTracer in its chain where it occurred, see errors.AtOrigin,
+// or else has the one of the caller of Error.
+// It is logged with the Stackdriver fields, and the httpRequest of r, to the slog default logger.
+// It is written to w as an application/problem+json response, see errors.WriteProblem,
+// when the request accepts JSON, otherwise its public message, by default the status text of its HTTP status,
+// is written as text, see errors.PublicMessage.
+func Error(w http.ResponseWriter, r *http.Request, err error) {
+	// Skip Error.
+	err = errors.WrapO(err, r.Method+" "+r.URL.Path, errors.WithContext(r.Context()), errors.AtOrigin(), errors.WithDepth(3))
+	err = errors.WithHTTPRequest(err, r)
+	slog.Default().LogAttrs(r.Context(), slog.LevelError, errors.RedactedMessage(err), errors.SlogAttrs(err)...)
+	if acceptsJSON(r) {
+		errors.WriteProblem(w, err)
+		return
+	}
+	http.Error(w, errors.PublicMessage(err), errors.HTTPStatus(err))
+}
+
+// acceptsJSON reports whether the Accept header of r lists application/json or application/problem+json.
+func acceptsJSON(r *http.Request) bool {
+	for _, v := range r.Header.Values("Accept") {
+		for _, media := range strings.Split(v, ",") {
+			media, _, _ = strings.Cut(media, ";")
+			switch strings.TrimSpace(media) {
+			case "application/json", errors.ProblemContentType:
+				return true
+			}
+		}
+	}
+	return false
+}
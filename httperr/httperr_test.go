@@ -0,0 +1,78 @@
+package httperr_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/httperr"
+)
+
+func ExampleHandlerFunc() {
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			// Keep the output stable.
+			switch a.Key {
+			case slog.MessageKey:
+				return a
+			case "logging.googleapis.com/sourceLocation":
+				return slog.String("function", a.Value.Any().(errors.SourceLocation).Function)
+			}
+			return slog.Attr{}
+		},
+	})))
+
+	h := httperr.Middleware(httperr.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return errors.WithCode(errors.New("user not found"), errors.NotFound)
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+	fmt.Print(w.Code, " ", w.Body)
+
+	// Output:
+	// {"msg":"GET /users/1: user not found","function":"github.com/bzon/errors/httperr_test.ExampleHandlerFunc.func2"}
+	// 404 Not Found
+}
+
+func ExampleHandlerFunc_abort() {
+	h := httperr.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		panic(http.ErrAbortHandler)
+	})
+
+	// The panic aborts the response, it is not handled as an error.
+	defer func() {
+		fmt.Println(recover() == http.ErrAbortHandler)
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/1", nil))
+
+	// Output:
+	// true
+}
+
+func ExampleRecoverer() {
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	h := httperr.Recoverer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	r.Header.Set("Accept", "application/json")
+	h.ServeHTTP(w, r)
+
+	p := errors.ProblemDetails{}
+	_ = json.Unmarshal(w.Body.Bytes(), &p)
+	fmt.Println(w.Code, w.Header().Get("Content-Type"))
+	fmt.Println(p.Code, p.Detail, p.Instance)
+
+	// Output:
+	// 500 application/problem+json
+	// INTERNAL Internal Server Error /users/1
+}
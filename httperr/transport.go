@@ -0,0 +1,93 @@
+package httperr
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"runtime"
+	"strings"
+
+	"github.com/bzon/errors"
+)
+
+// Transport is an http.RoundTripper that wraps the errors of its base transport,
+// see NewTransport.
+type Transport struct {
+	// Base is the wrapped transport, http.DefaultTransport when nil.
+	Base http.RoundTripper
+}
+
+// NewTransport returns a Transport that wraps the errors of rt.
+// The errors are wrapped with the request method and URL, without its user info, query and fragment,
+// and annotated on the span of the request context.
+// Their source location is the first caller outside of net/http and the round trippers.
+// Their code is Canceled, DeadlineExceeded or else Unavailable.
+//
+//	client := &http.Client{Transport: httperr.NewTransport(&ochttp.Transport{})}
+func NewTransport(rt http.RoundTripper) *Transport {
+	return &Transport{Base: rt}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, errors.WrapO(err, req.Method+" "+sanitizeURL(req),
+			transportCode(err),
+			errors.WithContext(req.Context()),
+			errors.WithSourceLocation(clientLocation()),
+		)
+	}
+	return resp, nil
+}
+
+// sanitizeURL returns the URL of req without its user info, query and fragment, which may hold credentials.
+func sanitizeURL(req *http.Request) string {
+	u := *req.URL
+	u.User = nil
+	u.RawQuery = ""
+	u.ForceQuery = false
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
+
+func transportCode(err error) errors.Code {
+	var nerr net.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		return errors.Canceled
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &nerr) && nerr.Timeout():
+		return errors.DeadlineExceeded
+	default:
+		return errors.Unavailable
+	}
+}
+
+// clientLocation returns the source location of the first caller outside of net/http and the round trippers.
+func clientLocation() errors.SourceLocation {
+	var pcs [32]uintptr
+	// Skip runtime.Callers, clientLocation and RoundTrip.
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		f, more := frames.Next()
+		internal := strings.HasPrefix(f.Function, "net/http.") ||
+			strings.HasPrefix(f.Function, "github.com/bzon/errors/httperr.") ||
+			strings.HasSuffix(f.Function, ".RoundTrip")
+		if !internal || !more {
+			return errors.SourceLocation{
+				Function: f.Function,
+				File:     f.File,
+				Line:     f.Line,
+				Version:  errors.VERSION,
+				Commit:   errors.COMMIT,
+				Branch:   errors.BRANCH,
+			}
+		}
+	}
+}
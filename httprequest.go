@@ -0,0 +1,78 @@
+package errors
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const logKeyHTTPRequest = "httpRequest"
+
+// HTTPRequest is the HTTP request in which an error occurred, in the format of the httpRequest field of
+// Cloud Logging. See https://cloud.google.com/logging/docs/reference/v2/rest/v2/LogEntry#HttpRequest.
+type HTTPRequest struct {
+	RequestMethod string `json:"requestMethod,omitempty"`
+	RequestURL    string `json:"requestUrl,omitempty"`
+	Status        int    `json:"status,omitempty"`
+	UserAgent     string `json:"userAgent,omitempty"`
+	RemoteIP      string `json:"remoteIp,omitempty"`
+	Referer       string `json:"referer,omitempty"`
+	// Latency is the duration of the request until the error, e.g. 0.250s.
+	Latency  string `json:"latency,omitempty"`
+	Protocol string `json:"protocol,omitempty"`
+}
+
+type requestStartKey struct{}
+
+// WithRequestStart returns a copy of ctx that carries the start time of the request,
+// for WithHTTPRequest to report its latency.
+func WithRequestStart(ctx context.Context, start time.Time) context.Context {
+	return context.WithValue(ctx, requestStartKey{}, start)
+}
+
+// WithHTTPRequest returns a copy of err that carries the HTTP request r, logged as the httpRequest field
+// by LogFields.
+// The status is the HTTP status of err, see HTTPStatus, and the latency is the time elapsed between
+// the start time of the context of r, see WithRequestStart, and the occurrence of err.
+// The URL is scrubbed by the redactors.
+// If err is not created by this package, it is wrapped with the source location of the caller.
+// It returns nil if err is nil.
+func WithHTTPRequest(err error, r *http.Request) error {
+	if err == nil {
+		return nil
+	}
+	e := withContext(err, wrappedFunctionCallDepth)
+	req := &HTTPRequest{
+		RequestMethod: r.Method,
+		RequestURL:    redact(logKeyHTTPRequest, r.URL.String()),
+		Status:        HTTPStatus(err),
+		UserAgent:     r.UserAgent(),
+		RemoteIP:      r.RemoteAddr,
+		Referer:       r.Referer(),
+		Protocol:      r.Proto,
+	}
+	if host, _, serr := net.SplitHostPort(r.RemoteAddr); serr == nil {
+		req.RemoteIP = host
+	}
+	if start, ok := r.Context().Value(requestStartKey{}).(time.Time); ok {
+		req.Latency = strconv.FormatFloat(e.OccurredAt().Sub(start).Seconds(), 'f', 3, 64) + "s"
+	}
+	e.httpRequest = req
+	return e
+}
+
+// HTTPRequestOf returns the HTTP request of the outermost error in the chain of err that has one,
+// see WithHTTPRequest.
+func HTTPRequestOf(err error) (HTTPRequest, bool) {
+	var req *HTTPRequest
+	visit(err, func(e *errorContext) bool {
+		req = e.httpRequest
+		return req != nil
+	})
+	if req == nil {
+		return HTTPRequest{}, false
+	}
+	return *req, true
+}
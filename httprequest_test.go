@@ -0,0 +1,20 @@
+package errors_test
+
+import (
+	"fmt"
+	"net/http/httptest"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleWithHTTPRequest() {
+	r := httptest.NewRequest("GET", "/users/1", nil)
+	r.Header.Set("User-Agent", "curl/8.0")
+
+	err := errors.WithHTTPRequest(errors.NewO("user not found", errors.NotFound), r)
+	req, _ := errors.HTTPRequestOf(err)
+	fmt.Printf("%+v\n", req)
+
+	// Output:
+	// {RequestMethod:GET RequestURL:/users/1 Status:404 UserAgent:curl/8.0 RemoteIP:192.0.2.1 Referer: Latency: Protocol:HTTP/1.1}
+}
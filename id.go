@@ -0,0 +1,97 @@
+package errors
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// idPrefix is the random half of the error IDs, that makes them unique across processes.
+	idPrefix = newIDPrefix()
+	// idCounter is the sequential half of the error IDs, that makes them unique within the process.
+	idCounter uint64
+)
+
+func newIDPrefix() uint64 {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return uint64(time.Now().UnixNano())
+	}
+	return binary.BigEndian.Uint64(b[:])
+}
+
+func nextID() uint64 {
+	return atomic.AddUint64(&idCounter, 1)
+}
+
+// origin returns the innermost errorContext in the chain of e, that is not a sentinel error, see Sentinel.
+func (e *errorContext) origin() *errorContext {
+	if o := originOf(e); o != nil {
+		return o
+	}
+	return e
+}
+
+// contextOf returns the outermost errorContext in the chain of err, or nil.
+func contextOf(err error) *errorContext {
+	// Most errors are errorContexts, that are found without walking the chain.
+	if e, ok := err.(*errorContext); ok {
+		return e
+	}
+	e, _ := AsType[*errorContext](err)
+	return e
+}
+
+// originOf returns the innermost errorContext in the chain of err that is not a sentinel error, or nil.
+func originOf(err error) *errorContext {
+	var o *errorContext
+	visit(err, func(c *errorContext) bool {
+		if !c.sentinel {
+			o = c
+		}
+		return false
+	})
+	return o
+}
+
+// IDOf returns the unique ID of the occurrence of err, or an empty string when err has none.
+// It is the ID of the innermost error of this package in the chain of err, where the error occurred,
+// that is kept by the copies and the wraps of err.
+func IDOf(err error) string {
+	if e := contextOf(err); e != nil {
+		return e.ID()
+	}
+	return ""
+}
+
+// TimeOf returns the time when err occurred, or the zero time when err has none.
+// It is the creation time of the innermost error of this package in the chain of err.
+func TimeOf(err error) time.Time {
+	if e := contextOf(err); e != nil {
+		return e.OccurredAt()
+	}
+	return time.Time{}
+}
+
+// ID returns the unique ID of the occurrence of the error, see IDOf.
+func (e *errorContext) ID() string {
+	return e.origin().ownID()
+}
+
+// ownID returns the ID of e, not of the origin of its chain.
+func (e *errorContext) ownID() string {
+	if e.remote != nil && e.remote.id != "" {
+		return e.remote.id
+	}
+	id := e.id
+	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x",
+		idPrefix>>32, idPrefix>>16&0xffff, idPrefix&0xffff, id>>48, id&0xffffffffffff)
+}
+
+// OccurredAt returns the time when the error occurred, see TimeOf.
+func (e *errorContext) OccurredAt() time.Time {
+	return e.origin().occurredAt
+}
@@ -0,0 +1,32 @@
+package errors_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleErrorTracer_iD() {
+	err := errors.New("a")
+	wrapped := errors.Wrap(err, "b")
+	other := errors.New("a")
+
+	id := errors.IDOf(err)
+	fmt.Println(errors.IDOf(wrapped) == id)
+	fmt.Println(errors.IDOf(other) == id)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleErrorTracer_occurredAt() {
+	start := time.Now()
+	err := errors.Wrap(errors.New("a"), "b")
+	at := errors.TimeOf(err)
+	fmt.Println(!at.Before(start), !at.After(time.Now()))
+
+	// Output:
+	// true true
+}
@@ -0,0 +1,92 @@
+package errors
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"reflect"
+	"sync/atomic"
+)
+
+// A CodeRule infers the code of an error of another package, e.g. NotFound for sql.ErrNoRows.
+// It reports false when it does not know the error.
+// When no error of a chain has a code, CodeOf calls the rules with the errors of the chain
+// that are not of this package, outermost first.
+type CodeRule func(err error) (Code, bool)
+
+// CodeRuleFor returns a rule that infers the code c for the errors that are target,
+// or that report being target with an Is(error) bool method, like the errors of syscall do.
+func CodeRuleFor(target error, c Code) CodeRule {
+	// Comparing errors of a type that is not comparable would panic, like in errors.Is.
+	isComparable := target != nil && reflect.TypeOf(target).Comparable()
+	return func(err error) (Code, bool) {
+		if isComparable && err == target {
+			return c, true
+		}
+		if x, ok := err.(interface{ Is(error) bool }); ok && x.Is(target) {
+			return c, true
+		}
+		return OK, false
+	}
+}
+
+// DefaultCodeRules returns the default of Options.CodeRules:
+//   - NotFound for sql.ErrNoRows and os.ErrNotExist.
+//   - AlreadyExists for os.ErrExist.
+//   - PermissionDenied for os.ErrPermission.
+//   - Canceled for context.Canceled.
+//   - DeadlineExceeded for context.DeadlineExceeded and os.ErrDeadlineExceeded.
+//
+// The codes of the gRPC status errors are inferred by adding grpcerr.CodeRule.
+func DefaultCodeRules() []CodeRule {
+	return []CodeRule{
+		CodeRuleFor(sql.ErrNoRows, NotFound),
+		CodeRuleFor(os.ErrNotExist, NotFound),
+		CodeRuleFor(os.ErrExist, AlreadyExists),
+		CodeRuleFor(os.ErrPermission, PermissionDenied),
+		CodeRuleFor(context.Canceled, Canceled),
+		CodeRuleFor(context.DeadlineExceeded, DeadlineExceeded),
+		CodeRuleFor(os.ErrDeadlineExceeded, DeadlineExceeded),
+	}
+}
+
+// defaultCodeRules are the DefaultCodeRules used when Options.CodeRules is nil.
+var defaultCodeRules = DefaultCodeRules()
+
+// codeRules is Options.CodeRules, read without copying the options on every CodeOf.
+var codeRules atomic.Pointer[[]CodeRule]
+
+// setCodeRules sets the rules of Options.CodeRules.
+func setCodeRules(rules []CodeRule) {
+	if rules == nil {
+		codeRules.Store(nil)
+		return
+	}
+	codeRules.Store(&rules)
+}
+
+// inferCode returns the code inferred by the rules of Options.CodeRules for the first error of the chain of err
+// that a rule knows, or OK. The errors of this package are skipped.
+func inferCode(err error) Code {
+	rules := defaultCodeRules
+	if p := codeRules.Load(); p != nil {
+		rules = *p
+	}
+	if len(rules) == 0 {
+		return OK
+	}
+	c := OK
+	walk(err, func(err error) bool {
+		if _, ok := err.(*errorContext); ok {
+			return false
+		}
+		for _, rule := range rules {
+			if code, ok := rule(err); ok {
+				c = code
+				return true
+			}
+		}
+		return false
+	})
+	return c
+}
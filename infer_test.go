@@ -0,0 +1,57 @@
+package errors_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleCodeRule() {
+	fmt.Println(errors.CodeOf(errors.Wrap(sql.ErrNoRows, "get user")))
+	fmt.Println(errors.CodeOf(errors.Wrap(context.DeadlineExceeded, "get user")))
+	_, err := os.ReadFile("testdata/missing.txt")
+	fmt.Println(errors.CodeOf(fmt.Errorf("read: %w", err)))
+	// The codes set explicitly take precedence.
+	fmt.Println(errors.CodeOf(errors.Wrap(errors.WithCode(sql.ErrNoRows, errors.Internal), "get user")))
+
+	// Output:
+	// NOT_FOUND
+	// DEADLINE_EXCEEDED
+	// NOT_FOUND
+	// INTERNAL
+}
+
+func ExampleCodeRuleFor() {
+	errQuota := fmt.Errorf("quota exceeded")
+	err := errors.Wrap(errQuota, "create user")
+	// The codes are inferred when they are read, with the rules configured then.
+	errors.Configure(errors.Options{
+		CodeRules: append(errors.DefaultCodeRules(), errors.CodeRuleFor(errQuota, errors.ResourceExhausted)),
+	})
+	defer errors.Configure(errors.Options{})
+
+	fmt.Println(errors.CodeOf(err))
+
+	errors.Configure(errors.Options{CodeRules: []errors.CodeRule{}})
+	fmt.Println(errors.CodeOf(errors.Wrap(sql.ErrNoRows, "get user")))
+
+	// Output:
+	// RESOURCE_EXHAUSTED
+	// UNKNOWN
+}
+
+// listError is an error of a type that is not comparable.
+type listError []string
+
+func (e listError) Error() string { return fmt.Sprint([]string(e)) }
+
+func ExampleCodeRuleFor_notComparable() {
+	rule := errors.CodeRuleFor(listError{"a"}, errors.InvalidArgument)
+	fmt.Println(rule(listError{"a"}))
+
+	// Output:
+	// OK false
+}
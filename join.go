@@ -0,0 +1,65 @@
+package errors
+
+import "errors"
+
+// Join is the drop-in replacement for errors.Join.
+// The returned ErrorTracer wraps the errors.Join multi-error, that implements Unwrap() []error,
+// so errors.Is and errors.As match any of errs.
+// Its Frames contain the source locations of errs.
+// It returns nil if every value in errs is nil.
+func Join(errs ...error) error {
+	err := errors.Join(errs...)
+	if err == nil {
+		return nil
+	}
+	e := newErrorContext(err, captureLocation(wrappedFunctionCallDepth))
+	return created(e, nil)
+}
+
+// AppendInto appends err to the error *errp, and reports whether err is not nil.
+// When both are not nil, *errp becomes a multi-error of both, like Join, located at the caller.
+// When only err is, *errp becomes err, wrapped with the source location of the caller
+// if it is not created by this package.
+// It combines the errors of a sequence of cleanups:
+//
+//	for _, c := range closers {
+//		errors.AppendInto(&err, c.Close())
+//	}
+//
+// The arguments of a deferred call are evaluated when it is deferred, defer AppendFunc instead.
+func AppendInto(errp *error, err error) bool {
+	return appendInto(errp, err, wrappedFunctionCallDepth)
+}
+
+// AppendFunc calls f and appends its error to the error *errp, like AppendInto.
+// It is meant to be deferred, to combine the error of a function with the error of a cleanup:
+//
+//	func writeFile(name string, b []byte) (err error) {
+//		f, err := os.Create(name)
+//		if err != nil {
+//			return errors.Wrap(err, "create")
+//		}
+//		defer errors.AppendFunc(&err, f.Close)
+//		_, err = f.Write(b)
+//		return errors.Wrap(err, "write")
+//	}
+func AppendFunc(errp *error, f func() error) {
+	appendInto(errp, f(), wrappedFunctionCallDepth)
+}
+
+func appendInto(errp *error, err error, depth int) bool {
+	if err == nil {
+		return false
+	}
+	if *errp == nil {
+		if _, ok := err.(*errorContext); ok {
+			*errp = err
+			return true
+		}
+		// Skip appendInto.
+		*errp = created(newErrorContext(err, captureLocation(depth+1)), nil)
+		return true
+	}
+	*errp = created(newErrorContext(errors.Join(*errp, err), captureLocation(depth+1)), nil)
+	return true
+}
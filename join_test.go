@@ -0,0 +1,92 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/bzon/errors"
+	"go.opencensus.io/trace"
+)
+
+func worker(i int) error {
+	if i%2 == 0 {
+		return nil
+	}
+	return errors.Errorf("worker %d failed", i)
+}
+
+func ExampleJoin() {
+	var errs []error
+	for i := 0; i < 4; i++ {
+		errs = append(errs, worker(i))
+	}
+	err := errors.Join(errs...)
+	fmt.Println(err)
+
+	e := err.(errors.ErrorTracer)
+	for _, frame := range errors.FramesOf(e) {
+		fmt.Println(frame.Function)
+	}
+
+	// Output:
+	// worker 1 failed
+	// worker 3 failed
+	// github.com/bzon/errors_test.ExampleJoin
+	// github.com/bzon/errors_test.worker
+	// github.com/bzon/errors_test.worker
+}
+
+func ExampleJoinT() {
+	_, span := trace.StartSpan(context.Background(), "foo")
+	defer span.End()
+
+	err := errors.JoinT(span, errSentinel, errors.WithCode(errors.New("a"), errors.NotFound))
+	fmt.Println(errors.Is(err, errSentinel))
+	fmt.Println(errors.CodeOf(err))
+	fmt.Println(errors.JoinT(span, nil, nil) == nil)
+
+	// Output:
+	// true
+	// NOT_FOUND
+	// true
+}
+
+type closer struct {
+	err error
+}
+
+func (c closer) Close() error {
+	return c.err
+}
+
+func save(f closer) (err error) {
+	defer errors.AppendFunc(&err, f.Close)
+	return errors.New("write failed")
+}
+
+func ExampleAppendFunc() {
+	err := save(closer{err: fmt.Errorf("close failed")})
+	fmt.Println(err)
+	fmt.Println(err.(errors.ErrorTracer).SourceLocation().Function)
+
+	fmt.Println(save(closer{}))
+
+	// Output:
+	// write failed
+	// close failed
+	// github.com/bzon/errors_test.save
+	// write failed
+}
+
+func ExampleAppendInto() {
+	var err error
+	fmt.Println(errors.AppendInto(&err, nil), err)
+	fmt.Println(errors.AppendInto(&err, io.ErrUnexpectedEOF), err)
+	fmt.Println(errors.AppendInto(&err, io.ErrClosedPipe), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.ErrClosedPipe))
+
+	// Output:
+	// false <nil>
+	// true unexpected EOF
+	// true true true
+}
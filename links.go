@@ -0,0 +1,43 @@
+package errors
+
+// LinkAnnotator is a SpanAnnotator that links the span of an error to the spans of the errors it wraps
+// that belong to other traces, e.g. the errors of another service rehydrated by Unmarshal, see Links.
+type LinkAnnotator interface {
+	SpanAnnotator
+	// AddLink links the span to the span of tc, of another trace.
+	AddLink(tc TraceContext)
+}
+
+// Links returns the trace contexts of the errors in the chain of err that belong to other traces than
+// the outermost trace context of the chain, one per trace, outermost first.
+// They are the spans of other services, or of other requests, that caused err.
+// It returns nil when there are none.
+func Links(err error) []TraceContext {
+	return links(err, traceContextOf(err).TraceID)
+}
+
+// traceContextOf returns the outermost trace context in the chain of err,
+// that outer errors created without a span do not have.
+func traceContextOf(err error) TraceContext {
+	var tc TraceContext
+	visit(err, func(e *errorContext) bool {
+		tc = e.TraceContext()
+		return tc.TraceID != ""
+	})
+	return tc
+}
+
+// links returns the trace contexts of the chain of err whose trace IDs are not traceID, one per trace.
+func links(err error, traceID string) []TraceContext {
+	var tcs []TraceContext
+	seen := map[string]bool{traceID: true}
+	visit(err, func(e *errorContext) bool {
+		tc := e.TraceContext()
+		if tc.TraceID != "" && !seen[tc.TraceID] {
+			seen[tc.TraceID] = true
+			tcs = append(tcs, tc)
+		}
+		return false
+	})
+	return tcs
+}
@@ -0,0 +1,26 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/errtest"
+)
+
+func ExampleLinks() {
+	// An error of another service, with its trace context.
+	remote := errors.NewO("user not found", errors.WithTraceContext(errors.TraceContext{
+		TraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:  "00f067aa0ba902b7",
+	}))
+
+	span := errtest.NewSpanRecorder()
+	err := errors.WrapO(remote, "get user", span.Option())
+
+	fmt.Println(errors.Links(err))
+	fmt.Println(span.Links())
+
+	// Output:
+	// [{4bf92f3577b34da6a3ce929d0e0e4736 00f067aa0ba902b7 0}]
+	// [{4bf92f3577b34da6a3ce929d0e0e4736 00f067aa0ba902b7 0}]
+}
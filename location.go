@@ -0,0 +1,215 @@
+package errors
+
+import (
+	"path"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// location is the source location of an error.
+// It is either a program counter that is symbolized when read, see sourceLocationPC,
+// or a SourceLocation that is symbolized already, e.g. of a remote error.
+type location struct {
+	pc  uintptr
+	src *SourceLocation
+}
+
+// captureLocation captures the source location at the given depth, like NewSourceLocation.
+// The depth is increased by Options.CallerSkip, and the frames of the packages skipped by SkipPackage are skipped.
+// The CallerSelector set by SetCallerSelector picks the frame among the frames at or above the depth.
+// Unless Options.LazySourceLocation is set, the location is symbolized right away.
+// It captures nothing with the tracedisabled build tag.
+func captureLocation(depth int) location {
+	if traceDisabled {
+		return location{}
+	}
+	depth += config().CallerSkip
+	if sel, _ := callerSelector.Load().(CallerSelector); sel != nil {
+		// Skip captureLocation.
+		return captureLocationSelecting(depth+1, sel)
+	}
+	if pkgs, _ := skippedPackages.Load().([]string); len(pkgs) > 0 {
+		// Skip captureLocation.
+		return captureLocationSkipping(depth+1, pkgs)
+	}
+	var pcs [1]uintptr
+	// runtime.Callers counts itself as a frame, unlike runtime.Caller.
+	if runtime.Callers(depth+1, pcs[:]) == 0 {
+		return location{}
+	}
+	if !config().LazySourceLocation {
+		sourceLocationPC(pcs[0])
+	}
+	return location{pc: pcs[0]}
+}
+
+// captureLocationSkipping captures the source location of the first frame at or above depth
+// that is not in one of pkgs.
+// The frames are symbolized right away, to tell the packages apart.
+func captureLocationSkipping(depth int, pkgs []string) location {
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(depth+1, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if frame.PC == 0 {
+			return location{}
+		}
+		if !more || !isSkipped(frame.Function, pkgs) {
+			src := SourceLocation{frame.Function, trimPath(frame.Function, frame.File), frame.Line, VERSION, COMMIT, BRANCH}
+			return location{src: &src}
+		}
+	}
+}
+
+// CallerSelector picks the frame of the source location of an error among frames,
+// the frames of the stack at or above the depth of the source location, innermost first,
+// without the frames of the packages skipped by SkipPackage.
+// frames[0] is the frame at the depth, the one picked by default.
+type CallerSelector func(frames []runtime.Frame) runtime.Frame
+
+var callerSelector atomic.Value
+
+// SetCallerSelector sets the package-global CallerSelector that picks the source locations of the errors,
+// so that the frameworks built on top of this package can pick the right frame without depth arithmetic.
+// A nil sel restores the default.
+//
+//	errors.SetCallerSelector(func(frames []runtime.Frame) runtime.Frame {
+//		for _, f := range frames {
+//			if !strings.HasPrefix(f.Function, "github.com/mycorp/framework.") {
+//				return f
+//			}
+//		}
+//		return frames[0]
+//	})
+func SetCallerSelector(sel CallerSelector) {
+	callerSelector.Store(sel)
+}
+
+// captureLocationSelecting captures the source location of the frame picked by sel among the frames
+// at or above depth, see CallerSelector.
+// It falls back to the first frame when sel returns a frame without a function.
+func captureLocationSelecting(depth int, sel CallerSelector) location {
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(depth+1, pcs[:])
+	if n == 0 {
+		return location{}
+	}
+	pkgs, _ := skippedPackages.Load().([]string)
+	frames := make([]runtime.Frame, 0, n)
+	it := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := it.Next()
+		if !isSkipped(frame.Function, pkgs) {
+			frames = append(frames, frame)
+		}
+		if !more {
+			break
+		}
+	}
+	if len(frames) == 0 {
+		return location{}
+	}
+	frame := sel(frames)
+	if frame.Function == "" {
+		frame = frames[0]
+	}
+	src := SourceLocation{frame.Function, trimPath(frame.Function, frame.File), frame.Line, VERSION, COMMIT, BRANCH}
+	return location{src: &src}
+}
+
+var (
+	skippedPackages   atomic.Value
+	skippedPackagesMu sync.Mutex
+)
+
+// SkipPackage skips the frames of the package with the import path pkg when capturing source locations,
+// so that the errors created by the helpers of pkg have the source location of the caller of the helpers.
+//
+//	errors.SkipPackage("github.com/mycorp/helpers")
+func SkipPackage(pkg string) {
+	skippedPackagesMu.Lock()
+	defer skippedPackagesMu.Unlock()
+	pkgs, _ := skippedPackages.Load().([]string)
+	skippedPackages.Store(append(pkgs[:len(pkgs):len(pkgs)], pkg))
+}
+
+// ResetSkippedPackages removes the packages skipped by SkipPackage.
+func ResetSkippedPackages() {
+	skippedPackagesMu.Lock()
+	defer skippedPackagesMu.Unlock()
+	skippedPackages.Store([]string(nil))
+}
+
+// isSkipped reports whether the function, e.g. github.com/mycorp/helpers.(*T).Wrap, is in one of pkgs.
+func isSkipped(function string, pkgs []string) bool {
+	pkg := funcPackage(function)
+	if pkg == "" {
+		return false
+	}
+	for _, p := range pkgs {
+		if p == pkg {
+			return true
+		}
+	}
+	return false
+}
+
+// funcPackage returns the import path of the package of function, e.g. github.com/mycorp/helpers
+// for github.com/mycorp/helpers.(*T).Wrap.
+func funcPackage(function string) string {
+	slash := strings.LastIndex(function, "/")
+	dot := strings.Index(function[slash+1:], ".")
+	if dot < 0 {
+		return ""
+	}
+	return function[:slash+1+dot]
+}
+
+// trimPath trims the file of function as set by Options.ModuleRelativePaths and Options.TrimPathPrefix.
+func trimPath(function, file string) string {
+	o := config()
+	if o.ModuleRelativePaths && mainModule != "" {
+		// The external test packages are in the directory of the package they test.
+		pkg := strings.TrimSuffix(funcPackage(function), "_test")
+		switch {
+		case pkg == mainModule:
+			return path.Base(file)
+		case strings.HasPrefix(pkg, mainModule+"/"):
+			return pkg[len(mainModule)+1:] + "/" + path.Base(file)
+		}
+	}
+	return strings.TrimPrefix(file, o.TrimPathPrefix)
+}
+
+func (l location) get() SourceLocation {
+	switch {
+	case l.src != nil:
+		return *l.src
+	case l.pc == 0:
+		return SourceLocation{Version: VERSION, Commit: COMMIT, Branch: BRANCH}
+	default:
+		return sourceLocationPC(l.pc)
+	}
+}
+
+// symbols caches the symbolized program counters, without the build information,
+// so that every call site is symbolized only once.
+var symbols sync.Map
+
+// sourceLocationPC creates a SourceLocation from a program counter returned by runtime.Callers.
+func sourceLocationPC(pc uintptr) SourceLocation {
+	var src SourceLocation
+	if v, ok := symbols.Load(pc); ok {
+		src = v.(SourceLocation)
+	} else {
+		frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+		src = SourceLocation{Function: frame.Function, File: frame.File, Line: frame.Line}
+		symbols.Store(pc, src)
+	}
+	src.File = trimPath(src.Function, src.File)
+	src.Version, src.Commit, src.Branch = VERSION, COMMIT, BRANCH
+	return src
+}
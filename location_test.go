@@ -0,0 +1,98 @@
+package errors_test
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleOptions_lazySourceLocation() {
+	errors.Configure(errors.Options{LazySourceLocation: true})
+	defer errors.Configure(errors.Options{})
+
+	err := errors.Wrap(callFoo(), "b")
+	e := err.(errors.ErrorTracer)
+	for _, frame := range errors.FramesOf(e) {
+		fmt.Println(frame.Function)
+	}
+
+	// Output:
+	// github.com/bzon/errors_test.ExampleOptions_lazySourceLocation
+	// github.com/bzon/errors_test.callFoo
+}
+
+// notFound is a helper of the application around the constructors of this package.
+func notFound(what string) error {
+	return errors.NewO(what+" not found", errors.NotFound)
+}
+
+func ExampleOptions_callerSkip() {
+	errors.Configure(errors.Options{CallerSkip: 1})
+	defer errors.Configure(errors.Options{})
+
+	err := notFound("user")
+	fmt.Println(err.(errors.ErrorTracer).SourceLocation().Function)
+
+	// Output:
+	// github.com/bzon/errors_test.ExampleOptions_callerSkip
+}
+
+func ExampleSkipPackage() {
+	errors.SkipPackage("github.com/bzon/errors_test")
+	defer errors.ResetSkippedPackages()
+
+	// All the frames of the examples are skipped, up to the testing package that runs them.
+	err := notFound("user")
+	fmt.Println(err.(errors.ErrorTracer).SourceLocation().Function)
+
+	// Output:
+	// testing.runExample
+}
+
+func ExampleOptions_moduleRelativePaths() {
+	errors.Configure(errors.Options{ModuleRelativePaths: true})
+	defer errors.Configure(errors.Options{})
+
+	err := errors.New("a")
+	fmt.Println(err.(errors.ErrorTracer).SourceLocation().File)
+
+	// Output:
+	// location_test.go
+}
+
+func ExampleOptions_trimPathPrefix() {
+	src := errors.NewSourceLocation(1)
+	errors.Configure(errors.Options{TrimPathPrefix: strings.TrimSuffix(src.File, "location_test.go")})
+	defer errors.Configure(errors.Options{})
+
+	err := errors.New("a")
+	fmt.Println(err.(errors.ErrorTracer).SourceLocation().File)
+
+	// Output:
+	// location_test.go
+}
+
+// wrapHelper is a helper of a framework, whose callers are the source locations of its errors.
+func wrapHelper(err error) error {
+	return errors.Wrap(err, "helper")
+}
+
+func ExampleSetCallerSelector() {
+	errors.SetCallerSelector(func(frames []runtime.Frame) runtime.Frame {
+		for _, f := range frames {
+			if !strings.HasSuffix(f.Function, ".wrapHelper") {
+				return f
+			}
+		}
+		return frames[0]
+	})
+	defer errors.SetCallerSelector(nil)
+
+	err := wrapHelper(errors.New("a"))
+	fmt.Println(err.(errors.ErrorTracer).SourceLocation().Function)
+
+	// Output:
+	// github.com/bzon/errors_test.ExampleSetCallerSelector
+}
@@ -0,0 +1,99 @@
+package errors
+
+// Stackdriver LogEntry special fields.
+// See https://cloud.google.com/logging/docs/agent/logging/configuration#special-fields.
+const (
+	logKeySpanID         = "logging.googleapis.com/spanId"
+	logKeyTrace          = "logging.googleapis.com/trace"
+	logKeyTraceSampled   = "logging.googleapis.com/trace_sampled"
+	logKeySourceLocation = "logging.googleapis.com/sourceLocation"
+	logKeyLabels         = "logging.googleapis.com/labels"
+	logKeyServiceContext = "serviceContext"
+	logKeySeverity       = "severity"
+	logKeyFingerprint    = "fingerprint"
+	logKeyErrorID        = "errorId"
+)
+
+// ServiceContext identifies the service reporting an error.
+// See https://cloud.google.com/error-reporting/reference/rest/v1beta1/ServiceContext.
+type ServiceContext struct {
+	Service string `json:"service"`
+	Version string `json:"version,omitempty"`
+}
+
+// traceName returns the trace resource name of traceID.
+// It returns traceID as is when no project ID is set.
+func traceName(traceID string) string {
+	id := config().ProjectID
+	if id == "" {
+		return traceID
+	}
+	return "projects/" + id + "/traces/" + traceID
+}
+
+// LogFields returns the Stackdriver severity and logging.googleapis.com/* key value pairs
+// of the outermost ErrorTracer in the chain of err, its httpRequest if any, see WithHTTPRequest,
+// and the fingerprint and the ID of err.
+// The serviceContext, labels and Datadog fields are added from the options set by Configure.
+// It returns nil when there is none.
+//
+//	logger.Log(append([]interface{}{"message", err.Error()}, errors.LogFields(err)...)...)
+func LogFields(err error) []interface{} {
+	e, ok := TracerOf(err)
+	if !ok {
+		return nil
+	}
+	AnnotateDeferred(err)
+	// Preallocate all the key value pairs.
+	fields := make([]interface{}, 0, 24)
+	fields = append(fields,
+		logKeySeverity, SeverityOf(err).String(),
+		logKeySourceLocation, e.SourceLocation(),
+	)
+	if tc := e.TraceContext(); tc.TraceID != "" {
+		fields = append(fields,
+			logKeyTrace, traceName(tc.TraceID),
+			logKeySpanID, tc.SpanID,
+			logKeyTraceSampled, tc.Sampled(),
+		)
+	}
+	if req, ok := HTTPRequestOf(err); ok {
+		fields = append(fields, logKeyHTTPRequest, req)
+	}
+	if r := RuntimeOf(e); !r.IsZero() {
+		fields = append(fields, logKeyRuntime, r)
+	}
+	o := config()
+	if o.DatadogFields {
+		fields = append(fields, e.TraceContext().DatadogFields()...)
+	}
+	fields = append(fields,
+		logKeyFingerprint, Fingerprint(err),
+		logKeyErrorID, IDOf(e),
+	)
+	if o.ServiceName != "" {
+		fields = append(fields, logKeyServiceContext, ServiceContext{
+			Service: o.ServiceName,
+			Version: VERSION,
+		})
+	}
+	if o.Environment != "" {
+		fields = append(fields, logKeyLabels, map[string]string{
+			"environment": o.Environment,
+		})
+	}
+	return fields
+}
+
+// LogFieldsMap is LogFields as a map.
+func LogFieldsMap(err error) map[string]interface{} {
+	fields := LogFields(err)
+	if fields == nil {
+		return nil
+	}
+	m := make(map[string]interface{}, len(fields)/2)
+	for i := 0; i < len(fields); i += 2 {
+		m[fields[i].(string)] = fields[i+1]
+	}
+	return m
+}
@@ -0,0 +1,51 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bzon/errors"
+	"go.opencensus.io/trace"
+)
+
+func ExampleLogFields() {
+	errors.SetProjectID("my-project")
+	defer errors.SetProjectID("")
+
+	err := errors.New("a")
+	e := err.(errors.ErrorTracer)
+	e.SetTraceContext(trace.SpanContext{
+		TraceID: [16]byte{'a', 'b', 'c'},
+		SpanID:  [8]byte{'d', 'e', 'f'},
+	})
+
+	fields := errors.LogFields(err)
+	for i := 0; i < len(fields); i += 2 {
+		// Skip the fields that depend on the file path or the occurrence.
+		switch fields[i] {
+		case "logging.googleapis.com/sourceLocation", "fingerprint", "errorId":
+			continue
+		}
+		fmt.Println(fields[i], fields[i+1])
+	}
+
+	// Output:
+	// severity ERROR
+	// logging.googleapis.com/trace projects/my-project/traces/61626300000000000000000000000000
+	// logging.googleapis.com/spanId 6465660000000000
+	// logging.googleapis.com/trace_sampled false
+}
+
+func ExampleLogFieldsMap() {
+	_, span := trace.StartSpan(context.Background(), "foo")
+	defer span.End()
+
+	err := errors.NewT(span, "a")
+	fields := errors.LogFieldsMap(err)
+	fmt.Println(fields["logging.googleapis.com/sourceLocation"].(errors.SourceLocation).Function)
+	fmt.Println(fields["logging.googleapis.com/spanId"] == span.SpanContext().SpanID.String())
+
+	// Output:
+	// github.com/bzon/errors_test.ExampleLogFieldsMap
+	// true
+}
@@ -0,0 +1,62 @@
+package errors
+
+// The keys of the error in the metadata of a message, see SetMessageAttributes and MessageHeaders.
+const (
+	// MessageKeyError is the key of the error encoded by Marshal.
+	MessageKeyError = "x-error"
+	// MessageKeyErrorCode is the key of the code of the error, to filter messages without decoding the error.
+	MessageKeyErrorCode = "x-error-code"
+)
+
+// MessageHeader is a header of a message, e.g. a Kafka record header.
+type MessageHeader struct {
+	Key   string
+	Value []byte
+}
+
+// SetMessageAttributes sets err, encoded and redacted by Marshal, and its code in the attributes of a message,
+// e.g. of a Pub/Sub message sent to a dead-letter topic.
+// The consumer reads the error back with FromMessageAttributes, with its trace context, code and source location.
+// It does nothing for a nil error.
+func SetMessageAttributes(attrs map[string]string, err error) {
+	if err == nil {
+		return
+	}
+	attrs[MessageKeyError] = string(Marshal(err))
+	attrs[MessageKeyErrorCode] = CodeOf(err).String()
+}
+
+// FromMessageAttributes returns the error set in attrs by SetMessageAttributes, see Unmarshal.
+// It returns nil when attrs has no error.
+func FromMessageAttributes(attrs map[string]string) error {
+	data, ok := attrs[MessageKeyError]
+	if !ok {
+		return nil
+	}
+	return Unmarshal([]byte(data))
+}
+
+// MessageHeaders returns the headers that carry err, encoded and redacted by Marshal, and its code,
+// to append to the headers of a message, e.g. of a Kafka record sent to a dead-letter topic.
+// The consumer reads the error back with FromMessageHeaders.
+// It returns nil for a nil error.
+func MessageHeaders(err error) []MessageHeader {
+	if err == nil {
+		return nil
+	}
+	return []MessageHeader{
+		{Key: MessageKeyError, Value: Marshal(err)},
+		{Key: MessageKeyErrorCode, Value: []byte(CodeOf(err).String())},
+	}
+}
+
+// FromMessageHeaders returns the error carried by headers, see MessageHeaders and Unmarshal.
+// It returns nil when headers have no error.
+func FromMessageHeaders(headers []MessageHeader) error {
+	for _, h := range headers {
+		if h.Key == MessageKeyError {
+			return Unmarshal(h.Value)
+		}
+	}
+	return nil
+}
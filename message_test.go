@@ -0,0 +1,40 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleSetMessageAttributes() {
+	err := errors.NewO("invalid payload", errors.InvalidArgument, errors.WithTraceContext(errors.TraceContext{
+		TraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:  "00f067aa0ba902b7",
+	}))
+
+	// The attributes of the message sent to the dead-letter topic.
+	attrs := map[string]string{}
+	errors.SetMessageAttributes(attrs, err)
+	fmt.Println(attrs[errors.MessageKeyErrorCode])
+
+	// The dead-letter consumer.
+	derr := errors.FromMessageAttributes(attrs)
+	e := derr.(errors.ErrorTracer)
+	fmt.Println(derr, errors.CodeOf(derr), e.TraceContext().TraceID)
+	fmt.Println(e.SourceLocation().Function)
+
+	// Output:
+	// INVALID_ARGUMENT
+	// invalid payload INVALID_ARGUMENT 4bf92f3577b34da6a3ce929d0e0e4736
+	// github.com/bzon/errors_test.ExampleSetMessageAttributes
+}
+
+func ExampleMessageHeaders() {
+	err := errors.NewO("invalid payload", errors.InvalidArgument)
+	headers := errors.MessageHeaders(err)
+
+	fmt.Println(errors.CodeOf(errors.FromMessageHeaders(headers)))
+
+	// Output:
+	// INVALID_ARGUMENT
+}
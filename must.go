@@ -0,0 +1,44 @@
+package errors
+
+import "errors"
+
+// Must returns v, and panics with err when it is not nil.
+// The error it panics with wraps err with the stack, see WithStack, and the source location of the caller.
+// It is meant for initialization paths and tests, where an error is a bug.
+//
+//	var tmpl = errors.Must(template.New("page").Parse(page))
+func Must[T any](v T, err error) T {
+	if err != nil {
+		panic(must(err, nil))
+	}
+	return v
+}
+
+// Ensure panics with an Internal error with the message m, the stack and the source location of the caller,
+// when cond is false.
+//
+//	errors.Ensure(len(shards) > 0, "no shards")
+func Ensure(cond bool, m string) {
+	if !cond {
+		panic(ensure(m, nil))
+	}
+}
+
+// must creates the error that Must panics with, located at the caller of Must.
+func must(err error, a SpanAnnotator) error {
+	// Skip must.
+	e := newErrorContext(err, captureLocation(wrappedFunctionCallDepth+1))
+	// Skip callers, must and Must.
+	e.setStack(callers(3))
+	return created(e, a)
+}
+
+// ensure creates the error that Ensure panics with, located at the caller of Ensure.
+func ensure(m string, a SpanAnnotator) error {
+	// Skip ensure.
+	e := newErrorContext(errors.New(m), captureLocation(wrappedFunctionCallDepth+1))
+	// Skip callers, ensure and Ensure.
+	e.setStack(callers(3))
+	e.code = Internal
+	return created(e, a)
+}
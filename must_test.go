@@ -0,0 +1,42 @@
+package errors_test
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleMust() {
+	defer func() {
+		err := recover().(error)
+		e := err.(errors.ErrorTracer)
+		fmt.Println(err)
+		if stack := errors.StackOf(e); len(stack) > 0 {
+			fmt.Println(e.SourceLocation().Function, stack[0].Function)
+		}
+	}()
+
+	n := errors.Must(strconv.Atoi("42"))
+	fmt.Println(n)
+	_ = errors.Must(strconv.Atoi("forty-two"))
+
+	// Output:
+	// 42
+	// strconv.Atoi: parsing "forty-two": invalid syntax
+	// github.com/bzon/errors_test.ExampleMust github.com/bzon/errors_test.ExampleMust
+}
+
+func ExampleEnsure() {
+	defer func() {
+		err := recover().(error)
+		fmt.Println(err, errors.CodeOf(err))
+		fmt.Println(err.(errors.ErrorTracer).SourceLocation().Function)
+	}()
+
+	errors.Ensure(1+1 == 3, "arithmetic is broken")
+
+	// Output:
+	// arithmetic is broken INTERNAL
+	// github.com/bzon/errors_test.ExampleEnsure
+}
@@ -0,0 +1,37 @@
+//go:build noopencensus
+
+package errors
+
+import (
+	"context"
+	"encoding/hex"
+)
+
+// SpanContext is the span context of SetTraceContext, WithSpanContext and ParseTraceParent.
+// The noopencensus build tag leaves out the OpenCensus API of this package, see opencensus.go,
+// so that the binaries that trace with another library do not link go.opencensus.io:
+//
+//	go build -tags noopencensus
+//
+// The errors are then annotated on spans with WithSpanAnnotator, e.g. errotel.WithSpan, and WithContext
+// sets the trace context carried by the context, see ContextWithTraceContext.
+type SpanContext = TraceContext
+
+// newSpanContext returns the SpanContext of a trace ID, a span ID and W3C trace flags.
+func newSpanContext(traceID [16]byte, spanID [8]byte, flags byte) SpanContext {
+	return TraceContext{
+		TraceID:    hex.EncodeToString(traceID[:]),
+		SpanID:     hex.EncodeToString(spanID[:]),
+		TraceFlags: flags,
+	}
+}
+
+// contextSpan returns nil, the contexts have no OpenCensus span.
+func contextSpan(ctx context.Context) SpanAnnotator {
+	return nil
+}
+
+// newTraceContext returns t.
+func newTraceContext(t SpanContext) TraceContext {
+	return t
+}
@@ -0,0 +1,64 @@
+package errors
+
+import "strings"
+
+// WithOp returns err tagged with the logical operation op, e.g. userservice.CreateUser.
+// If the outermost error of err is already tagged, err is wrapped, with the source location
+// of the caller, so that the chain of operations is kept, see Ops.
+// It returns nil if err is nil.
+func WithOp(err error, op string) error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := err.(*errorContext); ok && e.op != "" {
+		e := newErrorContext(err, captureLocation(wrappedFunctionCallDepth))
+		e.op = op
+		return created(e, nil)
+	}
+	e := withContext(err, wrappedFunctionCallDepth)
+	e.op = op
+	return e
+}
+
+// WrapOp wraps err, with the source location of the caller, tagged with the operation of the calling function,
+// e.g. userservice.Service.CreateUser for github.com/mycorp/app/userservice.(*Service).CreateUser,
+// so that the chain of operations, see Ops, builds itself without a message at every layer.
+// The message of err is kept as is.
+// It returns nil if err is nil.
+//
+//	func (s *Service) CreateUser(ctx context.Context, u User) error {
+//		if err := s.repo.Insert(ctx, u); err != nil {
+//			return errors.WrapOp(err)
+//		}
+func WrapOp(err error) error {
+	if err == nil {
+		return nil
+	}
+	loc := captureLocation(wrappedFunctionCallDepth)
+	e := newErrorContext(err, loc)
+	e.op = opName(loc.get().Function)
+	return created(e, nil)
+}
+
+// opName returns the operation of function: its name qualified by the name of its package,
+// without the pointer receiver, e.g. userservice.Service.CreateUser
+// for github.com/mycorp/app/userservice.(*Service).CreateUser.
+func opName(function string) string {
+	name := function[strings.LastIndex(function, "/")+1:]
+	return receiverReplacer.Replace(name)
+}
+
+// receiverReplacer removes the parentheses and the pointer of the receivers of method names.
+var receiverReplacer = strings.NewReplacer("(*", "", ")", "")
+
+// Ops returns the operations of the chain of err, outermost first.
+func Ops(err error) []string {
+	var ops []string
+	visit(err, func(e *errorContext) bool {
+		if e.op != "" {
+			ops = append(ops, e.op)
+		}
+		return false
+	})
+	return ops
+}
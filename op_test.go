@@ -0,0 +1,56 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleWithOp() {
+	err := errors.WithOp(errors.New("duplicate key"), "userrepo.Insert")
+	err = errors.WithOp(err, "userservice.CreateUser")
+	err = errors.Wrap(err, "create user")
+	err = errors.WithOp(err, "userhttp.Create")
+	fmt.Println(err)
+	fmt.Println(errors.Ops(err))
+
+	// Output:
+	// create user: duplicate key
+	// [userhttp.Create userservice.CreateUser userrepo.Insert]
+}
+
+type userService struct{}
+
+func (s *userService) CreateUser() error {
+	return errors.WrapOp(insertUser())
+}
+
+func insertUser() error {
+	return errors.WrapOp(errors.New("duplicate key"))
+}
+
+func ExampleWrapOp() {
+	err := (&userService{}).CreateUser()
+	fmt.Println(err)
+	fmt.Println(errors.Ops(err))
+	fmt.Println(len(errors.FramesOf(err)))
+
+	// Output:
+	// duplicate key
+	// [errors_test.userService.CreateUser errors_test.insertUser]
+	// 3
+}
+
+func ExampleWithOp_hooks() {
+	errors.AddHook(func(e errors.ErrorTracer) {
+		fmt.Println("created:", errors.Ops(e))
+	})
+	defer errors.ResetHooks()
+
+	err := errors.WithOp(errors.New("duplicate key"), "userrepo.Insert")
+	_ = errors.WithOp(err, "userservice.CreateUser")
+
+	// Output:
+	// created: []
+	// created: [userservice.CreateUser userrepo.Insert]
+}
@@ -0,0 +1,275 @@
+//go:build !noopencensus
+
+package errors
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opencensus.io/trace"
+)
+
+// SpanContext is the span context of SetTraceContext, WithSpanContext and ParseTraceParent:
+// the OpenCensus trace.SpanContext, or TraceContext with the noopencensus build tag, see noopencensus.go.
+type SpanContext = trace.SpanContext
+
+// newSpanContext returns the SpanContext of a trace ID, a span ID and W3C trace flags.
+func newSpanContext(traceID [16]byte, spanID [8]byte, flags byte) SpanContext {
+	return trace.SpanContext{TraceID: traceID, SpanID: spanID, TraceOptions: trace.TraceOptions(flags)}
+}
+
+// contextSpan returns the SpanAnnotator of the OpenCensus span of ctx, or nil if ctx has none.
+func contextSpan(ctx context.Context) SpanAnnotator {
+	return ocSpan(trace.FromContext(ctx))
+}
+
+// newTraceContext creates a TraceContext from an OpenCensus span context.
+func newTraceContext(t trace.SpanContext) TraceContext {
+	// hex.EncodeToString is much cheaper than the fmt based String methods of the IDs.
+	return TraceContext{
+		TraceID:    hex.EncodeToString(t.TraceID[:]),
+		SpanID:     hex.EncodeToString(t.SpanID[:]),
+		TraceFlags: byte(t.TraceOptions),
+	}
+}
+
+// ocAnnotator is the SpanAnnotator of an OpenCensus span.
+type ocAnnotator struct {
+	span *trace.Span
+}
+
+// ocSpan returns the SpanAnnotator of span, or nil if span is nil.
+func ocSpan(span *trace.Span) SpanAnnotator {
+	if span == nil {
+		return nil
+	}
+	return ocAnnotator{span: span}
+}
+
+func (a ocAnnotator) TraceContext() TraceContext {
+	return newTraceContext(a.span.SpanContext())
+}
+
+func (a ocAnnotator) AnnotateError(msg string, src SourceLocation, code Code) {
+	a.AnnotateErrorFields(msg, src, code, nil)
+}
+
+func (a ocAnnotator) AnnotateErrorFields(msg string, src SourceLocation, code Code, fields []Field) {
+	// Add OpenCensus span annotation.
+	a.span.Annotate(
+		[]trace.Attribute{
+			trace.StringAttribute("function", src.Function),
+			trace.StringAttribute("file", src.File),
+			trace.Int64Attribute("line", int64(src.Line)),
+			trace.StringAttribute("version", src.Version),
+			trace.StringAttribute("commit", src.Commit),
+			trace.StringAttribute("branch", src.Branch),
+		},
+		"Error: "+msg,
+	)
+
+	// Add the fields as span attributes, to query them in the tracing backend.
+	if len(fields) > 0 {
+		attrs := make([]trace.Attribute, len(fields))
+		for i, f := range fields {
+			attrs[i] = ocAttribute(f)
+		}
+		a.span.AddAttributes(attrs...)
+	}
+
+	// Generic error unless the error carries a code.
+	a.span.SetStatus(trace.Status{
+		Code: int32(code),
+	})
+}
+
+func (a ocAnnotator) AddLink(tc TraceContext) {
+	sc, err := ParseTraceParent(tc.TraceParent())
+	if err != nil {
+		return
+	}
+	a.span.AddLink(trace.Link{
+		TraceID: sc.TraceID,
+		SpanID:  sc.SpanID,
+	})
+}
+
+// ocAttribute converts f to an OpenCensus attribute.
+// Durations and times are formatted as strings, and the values of other types with fmt.Sprint.
+func ocAttribute(f Field) trace.Attribute {
+	switch v := f.Value.(type) {
+	case string:
+		return trace.StringAttribute(f.Key, v)
+	case bool:
+		return trace.BoolAttribute(f.Key, v)
+	case int64:
+		return trace.Int64Attribute(f.Key, v)
+	case int:
+		return trace.Int64Attribute(f.Key, int64(v))
+	case float64:
+		return trace.Float64Attribute(f.Key, v)
+	case time.Duration:
+		return trace.StringAttribute(f.Key, v.String())
+	case time.Time:
+		return trace.StringAttribute(f.Key, v.Format(time.RFC3339Nano))
+	default:
+		return trace.StringAttribute(f.Key, fmt.Sprint(v))
+	}
+}
+
+// Annotate annotates err on span and returns it as an ErrorTracer with the trace context of span,
+// e.g. to trace the errors of third-party libraries.
+// An error that is not created by this package is wrapped with the source location of the caller,
+// and the hooks are called with it. The errors of this package are copied, keeping their source location.
+// It returns nil if err is nil.
+//
+//	if err := client.Do(req); err != nil {
+//		return errors.Annotate(err, span)
+//	}
+func Annotate(err error, span *trace.Span) error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := err.(*errorContext); ok && !e.sentinel {
+		return annotate(e.clone(), ocSpan(span))
+	}
+	return created(withContext(err, wrappedFunctionCallDepth), ocSpan(span))
+}
+
+// NewCallerT wraps errors.New with a specified caller depth and a span trace context.
+func NewCallerT(depth int, span *trace.Span, m string) error {
+	err := newErrorContext(errors.New(m), captureLocation(depth))
+	return created(err, ocSpan(span))
+}
+
+// NewCallerfT wraps fmt.Errorf with a specified caller depth and a span trace context.
+func NewCallerfT(depth int, span *trace.Span, m string, args ...interface{}) error {
+	err := newErrorContext(fmt.Errorf(m, args...), captureLocation(depth))
+	err.format = m
+	return created(err, ocSpan(span))
+}
+
+// WrapCallerT wraps fmt.Errorf with a specified caller depth with a span trace context.
+// It returns nil if e is nil.
+func WrapCallerT(depth int, span *trace.Span, e error, m string) error {
+	if e == nil {
+		return nil
+	}
+	err := newErrorContext(wrapMessage(e, m), captureLocation(depth))
+	return created(err, ocSpan(span))
+}
+
+// WrapCallerfT wraps fmt.Errorf with a specified caller depth with a span trace context.
+// It returns nil if e is nil.
+func WrapCallerfT(depth int, span *trace.Span, e error, format string, args ...interface{}) error {
+	if e == nil {
+		return nil
+	}
+	m := fmt.Sprintf(format, args...)
+	err := newErrorContext(wrapMessage(e, m), captureLocation(depth))
+	return created(err, ocSpan(span))
+}
+
+// NewT wraps errors.New with a span trace context.
+func NewT(span *trace.Span, m string) error {
+	err := newErrorContext(errors.New(m), captureLocation(wrappedFunctionCallDepth))
+	return created(err, ocSpan(span))
+}
+
+// ErrorfT wraps fmt.Errorf with a span trace context.
+func ErrorfT(span *trace.Span, m string, args ...interface{}) error {
+	err := newErrorContext(fmt.Errorf(m, args...), captureLocation(wrappedFunctionCallDepth))
+	err.format = m
+	return created(err, ocSpan(span))
+}
+
+// WrapT wraps an error with a span trace context.
+// It returns nil if e is nil.
+func WrapT(span *trace.Span, e error, m string) error {
+	if e == nil {
+		return nil
+	}
+	err := newErrorContext(wrapMessage(e, m), captureLocation(wrappedFunctionCallDepth))
+	return created(err, ocSpan(span))
+}
+
+// WrapfT is Wrapf with a trace context.
+// It returns nil if e is nil.
+func WrapfT(span *trace.Span, e error, f string, args ...interface{}) error {
+	if e == nil {
+		return nil
+	}
+	m := fmt.Sprintf(f, args...)
+	err := newErrorContext(wrapMessage(e, m), captureLocation(wrappedFunctionCallDepth))
+	return created(err, ocSpan(span))
+}
+
+// JoinT is Join with a span trace context.
+// The span is annotated once with all of errs.
+func JoinT(span *trace.Span, errs ...error) error {
+	err := errors.Join(errs...)
+	if err == nil {
+		return nil
+	}
+	e := newErrorContext(err, captureLocation(wrappedFunctionCallDepth))
+	return created(e, ocSpan(span))
+}
+
+// MustT is Must with a span trace context, e.g. trace.FromContext(ctx).
+func MustT[T any](span *trace.Span, v T, err error) T {
+	if err != nil {
+		panic(must(err, ocSpan(span)))
+	}
+	return v
+}
+
+// EnsureT is Ensure with a span trace context, e.g. trace.FromContext(ctx).
+func EnsureT(span *trace.Span, cond bool, m string) {
+	if !cond {
+		panic(ensure(m, ocSpan(span)))
+	}
+}
+
+// RecoverT is Recover with a span trace context.
+func RecoverT(span *trace.Span, v interface{}) error {
+	if v == nil {
+		return nil
+	}
+	return recovered(v, ocSpan(span))
+}
+
+// GoT is Go with a span trace context.
+func GoT(span *trace.Span, fn func() error) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = recovered(v, ocSpan(span))
+		}
+	}()
+	return fn()
+}
+
+// WithStackT is WithStack with a span trace context.
+// It returns nil if err is nil.
+func WithStackT(span *trace.Span, err error) error {
+	if err == nil {
+		return nil
+	}
+	return created(withStack(err), ocSpan(span))
+}
+
+// EndSpan annotates the summary of the errors that were not annotated on span, see FlushSpanAnnotations,
+// and ends span.
+//
+//	defer errors.EndSpan(span)
+func EndSpan(span *trace.Span) {
+	FlushSpanAnnotations(ocSpan(span))
+	span.End()
+}
+
+// WithSpan annotates the error on span, like the T-suffixed constructors.
+func WithSpan(span *trace.Span) Option {
+	return WithSpanAnnotator(ocSpan(span))
+}
@@ -0,0 +1,34 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/bzon/errors"
+	"go.opencensus.io/trace"
+)
+
+func ExampleAnnotate() {
+	r := &spanRecorder{}
+	trace.RegisterExporter(r)
+	defer trace.UnregisterExporter(r)
+
+	_, span := trace.StartSpan(context.Background(), "read", trace.WithSampler(trace.AlwaysSample()))
+	err := errors.Annotate(io.ErrUnexpectedEOF, span)
+	span.End()
+
+	e := err.(errors.ErrorTracer)
+	fmt.Println(err, errors.Is(err, io.ErrUnexpectedEOF))
+	fmt.Println(e.SourceLocation().Function)
+	fmt.Println(e.TraceContext().SpanID == span.SpanContext().SpanID.String())
+	for _, a := range r.spans[0].Annotations {
+		fmt.Println(a.Message)
+	}
+
+	// Output:
+	// unexpected EOF true
+	// github.com/bzon/errors_test.ExampleAnnotate
+	// true
+	// Error: unexpected EOF
+}
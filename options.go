@@ -0,0 +1,179 @@
+package errors
+
+import "errors"
+
+// Option configures an error created by NewO, WrapO or WithO.
+// A Code is an Option that sets the code of the error, and a Severity one that sets its severity.
+type Option interface {
+	apply(*settings)
+}
+
+// settings are the settings of NewO, WrapO and WithO.
+type settings struct {
+	annotator    SpanAnnotator
+	depth        int
+	code         Code
+	httpStatus   int
+	severity     Severity
+	fields       Fields
+	location     *SourceLocation
+	traceContext TraceContext
+	noAnnotate   bool
+	noHooks      bool
+	atOrigin     bool
+}
+
+type optionFunc func(*settings)
+
+func (f optionFunc) apply(s *settings) {
+	f(s)
+}
+
+func (c Code) apply(s *settings) {
+	s.code = c
+}
+
+func (sev Severity) apply(s *settings) {
+	s.severity = sev
+}
+
+// WithStatusCode sets the HTTP status code of the error, like WithHTTPStatus.
+func WithStatusCode(status int) Option {
+	return optionFunc(func(s *settings) {
+		s.httpStatus = status
+	})
+}
+
+// WithSpanAnnotator annotates the error on the span of a, e.g. a span of another tracing library than OpenCensus.
+func WithSpanAnnotator(a SpanAnnotator) Option {
+	return optionFunc(func(s *settings) {
+		s.annotator = a
+	})
+}
+
+// NoAnnotate sets the trace context of the error from the span set by WithSpan or WithSpanAnnotator,
+// but does not annotate the error on the span nor set the span status.
+// It is meant for expected errors, like cache misses or retries.
+func NoAnnotate() Option {
+	return optionFunc(func(s *settings) {
+		s.noAnnotate = true
+	})
+}
+
+// NoHooks skips the hooks added with AddHook for the error.
+func NoHooks() Option {
+	return optionFunc(func(s *settings) {
+		s.noHooks = true
+	})
+}
+
+// AtOrigin sets the source location of the error to the one of the innermost ErrorTracer in the chain
+// of the error it wraps, where the error occurred, instead of capturing the caller.
+// It is meant for the middlewares and interceptors that wrap the errors of handlers, so that the errors
+// are logged and reported where they occurred rather than in the middleware. Frames skips the error.
+// The caller is captured as usual when the chain has no ErrorTracer.
+func AtOrigin() Option {
+	return optionFunc(func(s *settings) {
+		s.atOrigin = true
+	})
+}
+
+// WithDepth sets the caller depth of the source location, like the Caller constructors.
+func WithDepth(depth int) Option {
+	return optionFunc(func(s *settings) {
+		s.depth = depth
+	})
+}
+
+// WithFields adds fields to the error.
+func WithFields(fields Fields) Option {
+	return optionFunc(func(s *settings) {
+		s.fields = s.fields.merge(fields)
+	})
+}
+
+// WithSourceLocation sets the source location of the error instead of capturing it.
+// It is meant for errors that are created from the errors of another process.
+func WithSourceLocation(src SourceLocation) Option {
+	return optionFunc(func(s *settings) {
+		s.location = &src
+	})
+}
+
+// WithTraceContext sets the trace context of the error.
+// It is meant for errors that are created from the errors of another process.
+// A span set by WithSpan takes precedence.
+func WithTraceContext(tc TraceContext) Option {
+	return optionFunc(func(s *settings) {
+		s.traceContext = tc
+	})
+}
+
+// NewO wraps errors.New with options.
+//
+//	err := errors.NewO("user not found", errors.NotFound, errors.WithSpan(span))
+func NewO(m string, opts ...Option) error {
+	return newO(errors.New(m), opts)
+}
+
+// WrapO wraps an error fmt.Errorf with `%w` with options.
+// It returns nil if e is nil.
+func WrapO(e error, m string, opts ...Option) error {
+	if e == nil {
+		return nil
+	}
+	return newO(wrapMessage(e, m), opts)
+}
+
+// WithO wraps err with options, with the source location of the caller. The message of err is kept as is.
+// It is meant for the errors of other types that carry their own message, e.g. the instances of a catalog.
+// It returns nil if err is nil.
+//
+//	err := errors.WithO(&QuotaError{Limit: 10}, errors.ResourceExhausted, errors.SeverityWarning)
+func WithO(err error, opts ...Option) error {
+	if err == nil {
+		return nil
+	}
+	return newO(err, opts)
+}
+
+func newO(err error, opts []Option) error {
+	s := settings{depth: wrappedFunctionCallDepth}
+	for _, opt := range opts {
+		opt.apply(&s)
+	}
+	var (
+		loc    location
+		origin *errorContext
+	)
+	if s.atOrigin && s.location == nil {
+		origin = originOf(err)
+	}
+	switch {
+	case s.location != nil:
+		loc = location{src: s.location}
+	case origin != nil:
+		loc = origin.location()
+	default:
+		// Skip newO.
+		loc = captureLocation(s.depth + 1)
+	}
+	e := newErrorContext(err, loc)
+	e.atOrigin = origin != nil
+	e.traceContext = s.traceContext
+	e.code = s.code
+	e.httpStatus = s.httpStatus
+	e.severity = s.severity
+	e.fields = s.fields
+	a := s.annotator
+	if s.noAnnotate {
+		if a != nil {
+			e.traceContext = a.TraceContext()
+		}
+		a = nil
+	}
+	if s.noHooks {
+		return annotate(e, a)
+	}
+	return created(e, a)
+}
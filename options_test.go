@@ -0,0 +1,130 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bzon/errors"
+	"go.opencensus.io/trace"
+)
+
+func ExampleNewO() {
+	_, span := trace.StartSpan(context.Background(), "foo")
+	defer span.End()
+
+	err := errors.NewO("user not found",
+		errors.NotFound,
+		errors.WithSpan(span),
+		errors.WithFields(errors.Fields{"user": "jb"}),
+	)
+	fmt.Println(err)
+	fmt.Println(errors.CodeOf(err))
+	fmt.Println(errors.FieldsOf(err))
+	e := err.(errors.ErrorTracer)
+	fmt.Println(e.SourceLocation().Function)
+	fmt.Println(e.TraceContext().SpanID == span.SpanContext().SpanID.String())
+
+	// Output:
+	// user not found
+	// NOT_FOUND
+	// map[user:jb]
+	// github.com/bzon/errors_test.ExampleNewO
+	// true
+}
+
+func newNotFound() error {
+	return errors.NewO("not found", errors.WithDepth(3))
+}
+
+func ExampleWrapO() {
+	err := newNotFound()
+	err = errors.WrapO(err, "get user", errors.WithFields(errors.Fields{"user": "jb"}))
+	fmt.Println(err)
+	e := err.(errors.ErrorTracer)
+	for _, frame := range errors.FramesOf(e) {
+		fmt.Println(frame.Function)
+	}
+
+	// Output:
+	// get user: not found
+	// github.com/bzon/errors_test.ExampleWrapO
+	// github.com/bzon/errors_test.ExampleWrapO
+}
+
+// quotaError is an error of another type, that has its own message.
+type quotaError struct {
+	limit int
+}
+
+func (e *quotaError) Error() string { return fmt.Sprintf("quota of %d exceeded", e.limit) }
+
+func ExampleWithO() {
+	err := errors.WithO(&quotaError{limit: 10},
+		errors.ResourceExhausted,
+		errors.SeverityWarning,
+		errors.WithStatusCode(http.StatusServiceUnavailable),
+	)
+	fmt.Println(err)
+	fmt.Println(errors.CodeOf(err), errors.SeverityOf(err), errors.HTTPStatus(err))
+	fmt.Println(err.(errors.ErrorTracer).SourceLocation().Function)
+
+	// Output:
+	// quota of 10 exceeded
+	// RESOURCE_EXHAUSTED WARNING 503
+	// github.com/bzon/errors_test.ExampleWithO
+}
+
+func ExampleWithSourceLocation() {
+	err := errors.NewO("a",
+		errors.WithSourceLocation(errors.SourceLocation{Function: "remote.Func", File: "remote.go", Line: 1}),
+		errors.WithTraceContext(errors.TraceContext{TraceID: "61626300000000000000000000000000", SpanID: "6465660000000000"}),
+	)
+	e := err.(errors.ErrorTracer)
+	fmt.Println(e.SourceLocation().Function)
+	fmt.Println(e.TraceContext().SpanID)
+
+	// Output:
+	// remote.Func
+	// 6465660000000000
+}
+
+type spanRecorder struct {
+	spans []*trace.SpanData
+}
+
+func (r *spanRecorder) ExportSpan(s *trace.SpanData) {
+	r.spans = append(r.spans, s)
+}
+
+func ExampleNoAnnotate() {
+	r := &spanRecorder{}
+	trace.RegisterExporter(r)
+	defer trace.UnregisterExporter(r)
+
+	_, span := trace.StartSpan(context.Background(), "foo", trace.WithSampler(trace.AlwaysSample()))
+	err := errors.NewO("cache miss", errors.WithSpan(span), errors.NoAnnotate())
+	span.End()
+
+	e := err.(errors.ErrorTracer)
+	fmt.Println(e.TraceContext().SpanID == span.SpanContext().SpanID.String())
+	fmt.Println(len(r.spans[0].Annotations), r.spans[0].Status.Code)
+
+	// Output:
+	// true
+	// 0 0
+}
+
+func ExampleAtOrigin() {
+	err := errors.New("user not found")
+	// The wrapping of a middleware.
+	wrapped := errors.WrapO(err, "GET /users/1", errors.AtOrigin())
+
+	e := wrapped.(errors.ErrorTracer)
+	fmt.Println(e.SourceLocation() == err.(errors.ErrorTracer).SourceLocation())
+	fmt.Println(len(errors.FramesOf(e)))
+
+	// Output:
+	// true
+	// 1
+}
@@ -0,0 +1,82 @@
+package errors
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ANSI escape sequences of PrettyFormatter.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiFaint  = "\x1b[2m"
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+	ansiCyan   = "\x1b[36m"
+)
+
+// PrettyFormatter is a Formatter meant for development.
+// It writes the message on its own line, and every frame on a line, with the functions aligned,
+// followed by the source code snippet, see Options.SnippetLines.
+// With Color, the message is red, the frames of the application, see Options.AppPackages, are highlighted in cyan,
+// the frames of its dependencies and of the standard library are faint, and the lines of the frames
+// in the snippets are yellow.
+//
+//	errors.SetFormatter(errors.NewPrettyFormatter())
+type PrettyFormatter struct {
+	// Color writes ANSI colors.
+	Color bool
+}
+
+// NewPrettyFormatter returns a PrettyFormatter with colors when the standard error is a terminal.
+func NewPrettyFormatter() PrettyFormatter {
+	return PrettyFormatter{Color: isTerminal(os.Stderr)}
+}
+
+// isTerminal reports whether f is a character device, e.g. a terminal rather than a file or a pipe.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// Format implements Formatter.
+func (f PrettyFormatter) Format(w io.Writer, err ErrorTracer) {
+	frames := FramesOf(err)
+	width := 0
+	for _, src := range frames {
+		if len(src.Function) > width {
+			width = len(src.Function)
+		}
+	}
+	var b strings.Builder
+	b.WriteString(f.paint(ansiBold+ansiRed, err.Error()))
+	for _, src := range frames {
+		color := ansiCyan
+		if !IsAppFrame(src) {
+			color = ansiFaint
+		}
+		function := src.Function + strings.Repeat(" ", width-len(src.Function))
+		b.WriteString("\n  " + f.paint(color, function) + "  " + f.paint(ansiFaint, src.File+":"+strconv.Itoa(src.Line)))
+		snip := snippet(src)
+		if snip == "" {
+			continue
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(snip, "\n"), "\n") {
+			if strings.HasPrefix(line, ">") {
+				line = f.paint(ansiYellow, line)
+			}
+			b.WriteString("\n      " + line)
+		}
+	}
+	_, _ = io.WriteString(w, b.String())
+}
+
+// paint returns s in the color of the ANSI escape sequence color, when f.Color is set.
+func (f PrettyFormatter) paint(color, s string) string {
+	if !f.Color || s == "" {
+		return s
+	}
+	return color + s + ansiReset
+}
@@ -0,0 +1,39 @@
+package errors_test
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/bzon/errors"
+)
+
+func ExamplePrettyFormatter() {
+	errors.SetFormatter(errors.PrettyFormatter{})
+	defer errors.SetFormatter(nil)
+
+	err := errors.NewO("no rows", errors.WithSourceLocation(errors.SourceLocation{
+		Function: "database/sql.(*Row).Scan", File: "/usr/local/go/src/database/sql/sql.go", Line: 3367,
+	}))
+	err = errors.WrapO(err, "find user", errors.WithSourceLocation(errors.SourceLocation{
+		Function: "main.findUser", File: "user.go", Line: 12,
+	}))
+	fmt.Printf("%+v\n", err)
+
+	// Output:
+	// find user: no rows
+	//   main.findUser             user.go:12
+	//   database/sql.(*Row).Scan  /usr/local/go/src/database/sql/sql.go:3367
+}
+
+func ExamplePrettyFormatter_color() {
+	errors.SetFormatter(errors.PrettyFormatter{Color: true})
+	defer errors.SetFormatter(nil)
+
+	err := errors.WrapO(sql.ErrNoRows, "find user", errors.WithSourceLocation(errors.SourceLocation{
+		Function: "main.findUser", File: "user.go", Line: 12,
+	}))
+	fmt.Printf("%q\n", fmt.Sprintf("%+v", err))
+
+	// Output:
+	// "\x1b[1m\x1b[31mfind user: sql: no rows in result set\x1b[0m\n  \x1b[36mmain.findUser\x1b[0m  \x1b[2muser.go:12\x1b[0m"
+}
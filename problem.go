@@ -0,0 +1,96 @@
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// ProblemContentType is the media type of ProblemDetails.
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails is an RFC 7807 problem details object.
+// The trace context, the code and the ID of the error, and its field violations, are added as extension members.
+// See https://tools.ietf.org/html/rfc7807.
+type ProblemDetails struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	Code    string `json:"code,omitempty"`
+	TraceID string `json:"traceId,omitempty"`
+	SpanID  string `json:"spanId,omitempty"`
+	ErrorID string `json:"errorId,omitempty"`
+
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// InvalidParam is a field violation of a ProblemDetails, as in the example of RFC 7807.
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// ToProblem converts err to a ProblemDetails.
+// The type is the ProblemType() of the first error in the chain of err that has one, e.g. the documentation URL
+// of the errors of a catalog, or else about:blank.
+// The status is the HTTP status of err, see HTTPStatus, and the title is its status text.
+// The detail is the public message of err, see PublicMessage, so that the internal message of err is never
+// returned to clients. The instance is the path of the HTTP request of err, if it has one, see WithHTTPRequest.
+// The trace context is the trace context of the outermost ErrorTracer in the chain of err.
+// The invalid params are the field violations of the google.rpc.BadRequest details of err, see WithDetail.
+func ToProblem(err error) ProblemDetails {
+	status := HTTPStatus(err)
+	p := ProblemDetails{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+	if err == nil {
+		return p
+	}
+	AnnotateDeferred(err)
+	if t, ok := AsType[problemTyper](err); ok && t.ProblemType() != "" {
+		p.Type = t.ProblemType()
+	}
+	p.Detail = redact("", PublicMessage(err))
+	if req, ok := HTTPRequestOf(err); ok {
+		if u, uerr := url.Parse(req.RequestURL); uerr == nil {
+			p.Instance = u.Path
+		}
+	}
+	p.Code = CodeOf(err).String()
+	var e ErrorTracer
+	if As(err, &e) {
+		p.TraceID = e.TraceContext().TraceID
+		p.SpanID = e.TraceContext().SpanID
+		p.ErrorID = IDOf(e)
+	}
+	for _, d := range Details(err) {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, fv := range br.GetFieldViolations() {
+				p.InvalidParams = append(p.InvalidParams, InvalidParam{Name: fv.GetField(), Reason: fv.GetDescription()})
+			}
+		}
+	}
+	return p
+}
+
+// problemTyper is an error that has the type of its ProblemDetails, a URI that identifies the kind of the error.
+type problemTyper interface {
+	error
+	ProblemType() string
+}
+
+// WriteProblem writes err to w as an application/problem+json response, see ToProblem.
+func WriteProblem(w http.ResponseWriter, err error) {
+	p := ToProblem(err)
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
@@ -0,0 +1,47 @@
+package errors_test
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleToProblem() {
+	err := errors.WithCode(errors.New("select user 1: no rows"), errors.NotFound)
+	p := errors.ToProblem(err)
+	// The internal message is not returned to clients.
+	fmt.Println(p.Status, p.Title, p.Detail, p.Code)
+
+	p = errors.ToProblem(errors.WithPublicMessage(err, "user not found"))
+	fmt.Println(p.Detail)
+
+	// Output:
+	// 404 Not Found Not Found NOT_FOUND
+	// user not found
+}
+
+func ExampleToProblem_instance() {
+	err := errors.WithCode(errors.New("user not found"), errors.NotFound)
+	err = errors.WithHTTPRequest(err, httptest.NewRequest("GET", "/users/1?fields=name", nil))
+	fmt.Println(errors.ToProblem(err).Instance)
+
+	// Output:
+	// /users/1
+}
+
+func ExampleWriteProblem() {
+	w := httptest.NewRecorder()
+	err := errors.WithCode(errors.New("invalid email"), errors.InvalidArgument)
+	errors.WriteProblem(w, err)
+	fmt.Println(w.Code)
+	fmt.Println(w.Header().Get("Content-Type"))
+	// The error ID is unique for each occurrence.
+	fmt.Print(strings.Replace(w.Body.String(), errors.IDOf(err), "ID", 1))
+
+	// Output:
+	// 400
+	// application/problem+json
+	// {"type":"about:blank","title":"Bad Request","status":400,"detail":"Bad Request","code":"INVALID_ARGUMENT","errorId":"ID"}
+}
@@ -0,0 +1,46 @@
+package errors
+
+import (
+	"net/http"
+)
+
+// WithPublicMessage returns a copy of err that has the message msg to return to clients,
+// instead of the message of err that may contain internal details.
+// If err is not created by this package, it is wrapped with the source location of the caller.
+func WithPublicMessage(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	e := withContext(err, wrappedFunctionCallDepth)
+	e.publicMessage = msg
+	return e
+}
+
+// PublicMessage returns the public message of the outermost error in the chain of err that has one.
+// Otherwise it returns the status text of the HTTP status of err, see HTTPStatus,
+// so that the message of err is never returned to clients.
+// It returns an empty string for a nil error.
+func PublicMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	if m, ok := publicMessage(err); ok {
+		return m
+	}
+	return http.StatusText(HTTPStatus(err))
+}
+
+// HasPublicMessage reports whether an error in the chain of err has a public message.
+func HasPublicMessage(err error) bool {
+	_, ok := publicMessage(err)
+	return ok
+}
+
+func publicMessage(err error) (string, bool) {
+	var m string
+	ok := visit(err, func(e *errorContext) bool {
+		m = e.publicMessage
+		return m != ""
+	})
+	return m, ok
+}
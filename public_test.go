@@ -0,0 +1,23 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleWithPublicMessage() {
+	err := errors.Errorf("insert user 42: duplicate key value violates unique constraint %q", "users_email_key")
+	err = errors.WithCode(err, errors.AlreadyExists)
+	err = errors.WithPublicMessage(err, "a user with this email already exists")
+	err = errors.Wrap(err, "create user")
+
+	fmt.Println(errors.PublicMessage(err))
+	fmt.Println(errors.ToProblem(err).Detail)
+	fmt.Println(errors.PublicMessage(errors.New("connection reset")))
+
+	// Output:
+	// a user with this email already exists
+	// a user with this email already exists
+	// Internal Server Error
+}
@@ -0,0 +1,129 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/bzon/errors"
+	"go.opencensus.io/trace"
+)
+
+// TestConcurrentUse wraps, annotates and logs a shared error from many goroutines.
+// It is meant to run with the race detector: go test -race.
+func TestConcurrentUse(t *testing.T) {
+	base := errors.New("base")
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, span := trace.StartSpan(context.Background(), "op", trace.WithSampler(trace.AlwaysSample()))
+			defer span.End()
+
+			err := errors.WrapT(span, base, fmt.Sprint("call ", i))
+			err = errors.WithCode(err, errors.Unavailable)
+			err = errors.WithOp(err, "service.Call")
+			err = errors.WithSpanContext(err, span.SpanContext())
+			_ = errors.WithCode(base, errors.Internal)
+
+			logger.Error("failed", "error", err)
+			_ = errors.LogFields(err)
+			_ = errors.FieldsOf(err)
+			_ = fmt.Sprintf("%+v", err)
+			if !errors.Is(err, base) {
+				t.Errorf("%v is not %v", err, base)
+			}
+		}(i)
+	}
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			base.(errors.ErrorTracer).SetTraceContext(trace.SpanContext{TraceID: [16]byte{1}, SpanID: [8]byte{1}})
+			base.(errors.ErrorTracer).SetSourceLocation(2)
+		}()
+		go func() {
+			defer wg.Done()
+			e := base.(errors.ErrorTracer)
+			_ = e.TraceContext()
+			_ = e.SourceLocation()
+			_ = errors.StackTraceOf(e)
+			logger.Error("failed", "error", base)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConcurrentFields(t *testing.T) {
+	fields := errors.Fields{"user": 1}
+	err := errors.NewO("a", errors.WithFields(fields))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// The fields of an error are copies, that callers may modify.
+			f := errors.FieldsOf(err)
+			f["i"] = i
+		}(i)
+	}
+	fields["user"] = 2
+	wg.Wait()
+
+	if got := errors.FieldsOf(err)["user"]; got != 1 {
+		t.Errorf("user = %v, want 1", got)
+	}
+}
+
+// TestConcurrentSetReporter reports errors while the Reporter is replaced.
+// Every error is either reported, by one of the Reporters, or counted as dropped.
+func TestConcurrentSetReporter(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		counts int
+	)
+	r := errors.ReporterFunc(func(ctx context.Context, errs []error) error {
+		mu.Lock()
+		defer mu.Unlock()
+		counts += len(errs)
+		return nil
+	})
+	o := errors.ReporterOptions{QueueSize: 100000}
+	errors.SetReporter(r, o)
+	defer errors.SetReporter(nil, errors.ReporterOptions{})
+	before := errors.ReportingStats()
+
+	const n = 8 * 1000
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < n/8; j++ {
+				errors.Report(errors.New("a"))
+			}
+		}()
+	}
+	for i := 0; i < 200; i++ {
+		errors.SetReporter(r, o)
+	}
+	wg.Wait()
+	if err := errors.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	after := errors.ReportingStats()
+	dropped := int(after.Dropped - before.Dropped)
+	mu.Lock()
+	defer mu.Unlock()
+	if counts+dropped != n {
+		t.Errorf("reported %d and dropped %d errors, want %d in all", counts, dropped, n)
+	}
+}
@@ -0,0 +1,105 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+const maxStackDepth = 64
+
+// Recover converts the value returned by recover into an error.
+// The source location is where the panic happened, or the first frame of the application in the stack
+// with Options.AppSourceLocation, and the stack is the stack of the panicking goroutine.
+// It returns nil when v is nil.
+//
+//	defer func() {
+//		if err := errors.Recover(recover()); err != nil {
+//			// ...
+//		}
+//	}()
+func Recover(v interface{}) error {
+	if v == nil {
+		return nil
+	}
+	return recovered(v, nil)
+}
+
+// Go calls fn and returns its error.
+// A panic in fn is recovered and returned as an error, see Recover.
+// It is meant to be the body of a goroutine.
+//
+//	go func() {
+//		errc <- errors.Go(work)
+//	}()
+func Go(fn func() error) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = recovered(v, nil)
+		}
+	}()
+	return fn()
+}
+
+func recovered(v interface{}, a SpanAnnotator) error {
+	var cause error
+	if err, ok := v.(error); ok {
+		cause = fmt.Errorf("panic: %w", err)
+	} else {
+		cause = fmt.Errorf("panic: %v", v)
+	}
+
+	// Skip runtime.Callers and recovered.
+	stack := panicStack(callers(2))
+	var loc location
+	if len(stack) > 0 {
+		loc = location{pc: stack[0]}
+	}
+	err := newErrorContext(cause, loc)
+	err.code = Internal
+	err.setStack(stack)
+	return created(err, a)
+}
+
+var stackPool = sync.Pool{
+	New: func() interface{} {
+		return new([maxStackDepth]uintptr)
+	},
+}
+
+// callers returns the program counters of the calling goroutine's stack, skipping skip frames.
+// It returns nil with the tracedisabled build tag.
+func callers(skip int) []uintptr {
+	if traceDisabled {
+		return nil
+	}
+	buf := stackPool.Get().(*[maxStackDepth]uintptr)
+	defer stackPool.Put(buf)
+	n := runtime.Callers(skip+1, buf[:])
+	pcs := make([]uintptr, n)
+	copy(pcs, buf[:n])
+	return pcs
+}
+
+// panicStack trims the frames of the deferred function and of the runtime off pcs,
+// so that it starts where the panic happened.
+// It returns pcs as is when they are not of a panicking goroutine.
+func panicStack(pcs []uintptr) []uintptr {
+	for i, pc := range pcs {
+		if fn := runtime.FuncForPC(pc - 1); fn == nil || fn.Name() != "runtime.gopanic" {
+			continue
+		}
+		pcs = pcs[i+1:]
+		// Runtime errors, like a nil pointer dereference, panic from within the runtime.
+		for len(pcs) > 0 {
+			fn := runtime.FuncForPC(pcs[0] - 1)
+			if fn == nil || !strings.HasPrefix(fn.Name(), "runtime.") {
+				break
+			}
+			pcs = pcs[1:]
+		}
+		return pcs
+	}
+	return pcs
+}
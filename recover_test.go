@@ -0,0 +1,50 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+)
+
+func doPanic() {
+	panic("boom")
+}
+
+func ExampleRecover() {
+	err := func() (err error) {
+		defer func() {
+			err = errors.Recover(recover())
+		}()
+		doPanic()
+		return nil
+	}()
+	fmt.Println(err)
+	e := err.(errors.ErrorTracer)
+	fmt.Println(e.SourceLocation().Function)
+	if stack := errors.StackOf(e); len(stack) > 1 {
+		fmt.Println(stack[1].Function)
+	}
+
+	// Output:
+	// panic: boom
+	// github.com/bzon/errors_test.doPanic
+	// github.com/bzon/errors_test.ExampleRecover.func1
+}
+
+func ExampleGo() {
+	errc := make(chan error, 1)
+	go func() {
+		errc <- errors.Go(func() error {
+			var m map[string]int
+			m["a"] = 1
+			return nil
+		})
+	}()
+	err := <-errc
+	fmt.Println(err)
+	fmt.Println(errors.CodeOf(err))
+
+	// Output:
+	// panic: assignment to entry in nil map
+	// INTERNAL
+}
@@ -0,0 +1,109 @@
+package errors
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Redacted replaces the values scrubbed by the redactors of RedactKeys.
+const Redacted = "[REDACTED]"
+
+// Redactor scrubs secrets and personal data from a message or a field value.
+// The key is the key of the field, or empty for a message.
+type Redactor func(key, value string) string
+
+var (
+	redactors   atomic.Value
+	redactorsMu sync.Mutex
+)
+
+// AddRedactor adds r to the package-global redactors.
+// The redactors are applied, in order, to the messages written to span annotations,
+// LogValue, ReportedErrorEvent and ProblemDetails, and by RedactedMessage and RedactedFields.
+func AddRedactor(r Redactor) {
+	redactorsMu.Lock()
+	defer redactorsMu.Unlock()
+	rs, _ := redactors.Load().([]Redactor)
+	redactors.Store(append(rs[:len(rs):len(rs)], r))
+}
+
+// ResetRedactors removes the package-global redactors.
+func ResetRedactors() {
+	redactorsMu.Lock()
+	defer redactorsMu.Unlock()
+	redactors.Store([]Redactor(nil))
+}
+
+// RedactPattern returns a Redactor that replaces the matches of re with repl, see regexp.Regexp.ReplaceAllString.
+//
+//	errors.AddRedactor(errors.RedactPattern(regexp.MustCompile(`[\w.+-]+@[\w-]+\.[\w.]+`), "[EMAIL]"))
+func RedactPattern(re *regexp.Regexp, repl string) Redactor {
+	return func(_, value string) string {
+		return re.ReplaceAllString(value, repl)
+	}
+}
+
+// RedactKeys returns a Redactor that replaces the values of the fields with the given keys,
+// compared case-insensitively, with Redacted.
+//
+//	errors.AddRedactor(errors.RedactKeys("password", "token"))
+func RedactKeys(keys ...string) Redactor {
+	set := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		set[strings.ToLower(k)] = true
+	}
+	return func(key, value string) string {
+		if set[strings.ToLower(key)] {
+			return Redacted
+		}
+		return value
+	}
+}
+
+// redact applies the package-global redactors to the value of the field key, or to a message.
+func redact(key, value string) string {
+	rs, _ := redactors.Load().([]Redactor)
+	for _, r := range rs {
+		value = r(key, value)
+	}
+	return value
+}
+
+// RedactedMessage returns the message of err scrubbed by the package-global redactors.
+// It returns an empty string for a nil error.
+func RedactedMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return redact("", err.Error())
+}
+
+// RedactedFields returns the fields of err, see FieldsOf, scrubbed by the package-global redactors.
+// Values that are not strings are formatted with fmt.Sprint, and are replaced only when they are scrubbed.
+func RedactedFields(err error) Fields {
+	fields := FieldsOf(err)
+	if fields == nil {
+		return nil
+	}
+	rs, _ := redactors.Load().([]Redactor)
+	if len(rs) == 0 {
+		return fields
+	}
+	redacted := make(Fields, len(fields))
+	for k, v := range fields {
+		if s, ok := v.(string); ok {
+			redacted[k] = redact(k, s)
+			continue
+		}
+		s := fmt.Sprint(v)
+		if r := redact(k, s); r != s {
+			redacted[k] = r
+			continue
+		}
+		redacted[k] = v
+	}
+	return redacted
+}
@@ -0,0 +1,26 @@
+package errors_test
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleAddRedactor() {
+	errors.AddRedactor(errors.RedactPattern(regexp.MustCompile(`[\w.+-]+@[\w-]+\.[\w.]+`), "[EMAIL]"))
+	errors.AddRedactor(errors.RedactKeys("password"))
+	defer errors.ResetRedactors()
+
+	err := errors.NewO("invalid login of jane@example.com",
+		errors.WithFields(errors.Fields{"password": "hunter2", "attempts": 3}),
+	)
+	fmt.Println(errors.RedactedMessage(err))
+	fmt.Println(errors.RedactedFields(err))
+	fmt.Println(errors.ToProblem(errors.WithPublicMessage(err, "invalid login of jane@example.com")).Detail)
+
+	// Output:
+	// invalid login of [EMAIL]
+	// map[attempts:3 password:[REDACTED]]
+	// invalid login of [EMAIL]
+}
@@ -0,0 +1,296 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Reporter reports errors to a backend, e.g. an error tracking service.
+// It is called by the dispatcher set with SetReporter, with batches of the errors passed to Report.
+type Reporter interface {
+	Report(ctx context.Context, errs []error) error
+}
+
+// ReporterFunc is a function that is a Reporter.
+type ReporterFunc func(ctx context.Context, errs []error) error
+
+// Report calls f(ctx, errs).
+func (f ReporterFunc) Report(ctx context.Context, errs []error) error {
+	return f(ctx, errs)
+}
+
+// ReporterOptions are the options of the dispatching of errors to a Reporter, see SetReporter.
+type ReporterOptions struct {
+	// QueueSize is the number of errors that can wait to be reported, 1024 by default.
+	// The errors passed to Report when the queue is full are dropped.
+	QueueSize int
+	// BatchSize is the maximum number of errors reported at once, 100 by default.
+	BatchSize int
+	// FlushInterval is the maximum time an error waits for its batch to be full, 5 seconds by default.
+	FlushInterval time.Duration
+	// Timeout is the deadline of the context passed to the Reporter for each batch, 30 seconds by default.
+	Timeout time.Duration
+}
+
+// ReportStats are the counts of the errors passed to Report.
+type ReportStats struct {
+	// Reported is the number of errors the Reporter reported.
+	Reported uint64
+	// Dropped is the number of errors that were dropped because the queue was full,
+	// or because the reporting was stopped by SetReporter while they were passed to Report.
+	Dropped uint64
+	// Failed is the number of errors the Reporter failed to report.
+	Failed uint64
+}
+
+type dispatcher struct {
+	reporter Reporter
+	options  ReporterOptions
+	queue    chan error
+	flush    chan chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+	// mu is held for reading while an error is queued, so that no error is queued once stopped is set
+	// and the queue is drained for the last time.
+	mu      sync.RWMutex
+	stopped bool
+}
+
+var (
+	dispatchers  atomic.Value
+	dispatcherMu sync.Mutex
+
+	reported, dropped, failed uint64
+)
+
+// SetReporter sets the package-global Reporter that the errors passed to Report are sent to.
+// The errors are queued and sent in batches, asynchronously, see ReporterOptions.
+// The errors queued for the previous Reporter are sent to it before SetReporter returns,
+// and the errors passed to Report meanwhile are queued for r.
+// A nil Reporter stops the reporting.
+func SetReporter(r Reporter, o ReporterOptions) {
+	if o.QueueSize <= 0 {
+		o.QueueSize = 1024
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 100
+	}
+	if o.FlushInterval <= 0 {
+		o.FlushInterval = 5 * time.Second
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = 30 * time.Second
+	}
+
+	dispatcherMu.Lock()
+	defer dispatcherMu.Unlock()
+	if d, _ := dispatchers.Load().(*dispatcher); d != nil {
+		d.mu.Lock()
+		d.stopped = true
+		d.mu.Unlock()
+		close(d.stop)
+		<-d.done
+	}
+	if r == nil {
+		dispatchers.Store((*dispatcher)(nil))
+		return
+	}
+	d := &dispatcher{
+		reporter: r,
+		options:  o,
+		queue:    make(chan error, o.QueueSize),
+		flush:    make(chan chan struct{}),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	dispatchers.Store(d)
+	go d.run()
+}
+
+// Report queues err to be reported by the Reporter set with SetReporter, without blocking.
+// It does nothing for a nil error or when no Reporter is set.
+func Report(err error) {
+	d, _ := dispatchers.Load().(*dispatcher)
+	if err == nil || d == nil {
+		return
+	}
+	AnnotateDeferred(err)
+	if d.enqueue(err) {
+		return
+	}
+	// SetReporter is replacing d: wait for the next dispatcher.
+	dispatcherMu.Lock()
+	d, _ = dispatchers.Load().(*dispatcher)
+	dispatcherMu.Unlock()
+	if d == nil || !d.enqueue(err) {
+		atomic.AddUint64(&dropped, 1)
+	}
+}
+
+// enqueue queues err, or counts it as dropped when the queue is full.
+// It reports false, without queuing err, when d is stopped.
+func (d *dispatcher) enqueue(err error) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.stopped {
+		return false
+	}
+	select {
+	case d.queue <- err:
+	default:
+		atomic.AddUint64(&dropped, 1)
+	}
+	return true
+}
+
+// Flush sends the queued errors to the Reporter set with SetReporter and waits until they are reported,
+// or until ctx is done.
+func Flush(ctx context.Context) error {
+	d, _ := dispatchers.Load().(*dispatcher)
+	if d == nil {
+		return nil
+	}
+	ack := make(chan struct{})
+	select {
+	case d.flush <- ack:
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-ack:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ReportingStats returns the counts of the errors passed to Report.
+func ReportingStats() ReportStats {
+	return ReportStats{
+		Reported: atomic.LoadUint64(&reported),
+		Dropped:  atomic.LoadUint64(&dropped),
+		Failed:   atomic.LoadUint64(&failed),
+	}
+}
+
+func (d *dispatcher) run() {
+	defer close(d.done)
+	ticker := time.NewTicker(d.options.FlushInterval)
+	defer ticker.Stop()
+
+	var batch []error
+	add := func(err error) {
+		batch = append(batch, err)
+		if len(batch) >= d.options.BatchSize {
+			d.send(batch)
+			batch = nil
+		}
+	}
+	drain := func() {
+		for {
+			select {
+			case err := <-d.queue:
+				add(err)
+			default:
+				if len(batch) > 0 {
+					d.send(batch)
+					batch = nil
+				}
+				return
+			}
+		}
+	}
+	for {
+		select {
+		case err := <-d.queue:
+			add(err)
+		case <-ticker.C:
+			drain()
+		case ack := <-d.flush:
+			drain()
+			close(ack)
+		case <-d.stop:
+			drain()
+			return
+		}
+	}
+}
+
+// send reports batch within ReporterOptions.Timeout, recovering from a panic of the Reporter.
+func (d *dispatcher) send(batch []error) {
+	n := uint64(len(batch))
+	defer func() {
+		if v := recover(); v != nil {
+			atomic.AddUint64(&failed, n)
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), d.options.Timeout)
+	defer cancel()
+	if err := d.reporter.Report(ctx, batch); err != nil {
+		atomic.AddUint64(&failed, n)
+		return
+	}
+	atomic.AddUint64(&reported, n)
+}
+
+// NewLogReporter returns a Reporter that writes the errors to w as JSON lines of ReportedErrorEvent,
+// see ToReportedErrorEvent. On Google Cloud, the lines written to stdout are ingested by
+// Cloud Logging and grouped by Error Reporting.
+func NewLogReporter(w io.Writer) Reporter {
+	var mu sync.Mutex
+	return ReporterFunc(func(ctx context.Context, errs []error) error {
+		var b bytes.Buffer
+		enc := json.NewEncoder(&b)
+		for _, err := range errs {
+			if err := enc.Encode(ToReportedErrorEvent(err)); err != nil {
+				return err
+			}
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		_, err := w.Write(b.Bytes())
+		return err
+	})
+}
+
+// NewWebhookReporter returns a Reporter that posts the errors to url as a JSON array of ReportedErrorEvent,
+// see ToReportedErrorEvent. A client with a timeout of 30 seconds is used when client is nil.
+func NewWebhookReporter(url string, client *http.Client) Reporter {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return ReporterFunc(func(ctx context.Context, errs []error) error {
+		events := make([]ReportedErrorEvent, len(errs))
+		for i, err := range errs {
+			events[i] = ToReportedErrorEvent(err)
+		}
+		body, err := json.Marshal(events)
+		if err != nil {
+			return err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		_, _ = io.Copy(io.Discard, resp.Body)
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("webhook %s: %s", url, resp.Status)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,82 @@
+package errors_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleSetReporter() {
+	errors.SetReporter(errors.ReporterFunc(func(ctx context.Context, errs []error) error {
+		fmt.Println("reported", len(errs), "errors")
+		return nil
+	}), errors.ReporterOptions{BatchSize: 2})
+	defer errors.SetReporter(nil, errors.ReporterOptions{})
+
+	before := errors.ReportingStats()
+	for i := 0; i < 3; i++ {
+		errors.Report(errors.New("a"))
+	}
+	_ = errors.Flush(context.Background())
+	fmt.Println(errors.ReportingStats().Reported - before.Reported)
+
+	// Output:
+	// reported 2 errors
+	// reported 1 errors
+	// 3
+}
+
+func ExampleNewLogReporter() {
+	errors.SetReporter(errors.NewLogReporter(os.Stdout), errors.ReporterOptions{})
+	defer errors.SetReporter(nil, errors.ReporterOptions{})
+
+	errors.Report(fmt.Errorf("a"))
+	_ = errors.Flush(context.Background())
+
+	// Output:
+	// {"@type":"type.googleapis.com/google.devtools.clouderrorreporting.v1beta1.ReportedErrorEvent","serviceContext":{"service":"","version":"UNKNOWN"},"message":"a"}
+}
+
+func ExampleNewWebhookReporter() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var events []errors.ReportedErrorEvent
+		_ = json.NewDecoder(r.Body).Decode(&events)
+		for _, event := range events {
+			fmt.Println(strings.SplitN(event.Message, "\n", 2)[0])
+		}
+	}))
+	defer srv.Close()
+
+	r := errors.NewWebhookReporter(srv.URL, nil)
+	err := r.Report(context.Background(), []error{errors.New("a"), io.EOF})
+	fmt.Println(err)
+
+	// Output:
+	// a
+	// EOF
+	// <nil>
+}
+
+func ExampleReporterOptions_timeout() {
+	errors.SetReporter(errors.ReporterFunc(func(ctx context.Context, errs []error) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}), errors.ReporterOptions{Timeout: time.Millisecond})
+	defer errors.SetReporter(nil, errors.ReporterOptions{})
+
+	before := errors.ReportingStats()
+	errors.Report(errors.New("a"))
+	_ = errors.Flush(context.Background())
+	fmt.Println(errors.ReportingStats().Failed - before.Failed)
+
+	// Output:
+	// 1
+}
@@ -0,0 +1,97 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// ReportedErrorEventType is the @type of a log entry payload that is reported to Google Cloud Error Reporting.
+const ReportedErrorEventType = "type.googleapis.com/google.devtools.clouderrorreporting.v1beta1.ReportedErrorEvent"
+
+// ReportedErrorEvent is an error event in the format of Google Cloud Error Reporting.
+// Logging it as the JSON payload of a log entry makes Error Reporting group the error.
+// See https://cloud.google.com/error-reporting/docs/formatting-error-messages.
+type ReportedErrorEvent struct {
+	Type           string                `json:"@type"`
+	ServiceContext ServiceContext        `json:"serviceContext"`
+	Message        string                `json:"message"`
+	EventTime      string                `json:"eventTime,omitempty"`
+	Context        *ReportedErrorContext `json:"context,omitempty"`
+}
+
+// ReportedErrorContext is the context in which an error was reported.
+type ReportedErrorContext struct {
+	ReportLocation *ReportLocation `json:"reportLocation,omitempty"`
+}
+
+// ReportLocation is the location in the source code where an error was reported.
+type ReportLocation struct {
+	FilePath     string `json:"filePath"`
+	LineNumber   int    `json:"lineNumber"`
+	FunctionName string `json:"functionName"`
+}
+
+// ToReportedErrorEvent converts err to a ReportedErrorEvent.
+// The service context is filled from the options set by Configure, and the event time is the time
+// when err occurred.
+// The message contains the stack trace of err if it has one, otherwise the report location is set
+// from the source location of the outermost ErrorTracer in the chain of err.
+func ToReportedErrorEvent(err error) ReportedErrorEvent {
+	event := ReportedErrorEvent{
+		Type: ReportedErrorEventType,
+		ServiceContext: ServiceContext{
+			Service: config().ServiceName,
+			Version: VERSION,
+		},
+	}
+	if err == nil {
+		return event
+	}
+	AnnotateDeferred(err)
+	event.Message = RedactedMessage(err)
+
+	var e *errorContext
+	if !As(err, &e) {
+		return event
+	}
+	event.EventTime = e.OccurredAt().UTC().Format(time.RFC3339Nano)
+	if stack := stackTrace(err); stack != "" {
+		event.Message += "\n\n" + stack
+		return event
+	}
+	src := e.SourceLocation()
+	event.Context = &ReportedErrorContext{
+		ReportLocation: &ReportLocation{
+			FilePath:     src.File,
+			LineNumber:   src.Line,
+			FunctionName: src.Function,
+		},
+	}
+	return event
+}
+
+// stackTrace formats the first stack found in the chain of err like runtime/debug.Stack does.
+// It returns an empty string when there is none.
+func stackTrace(err error) string {
+	var pcs []uintptr
+	visit(err, func(e *errorContext) bool {
+		pcs = e.stack
+		return len(pcs) > 0
+	})
+	if len(pcs) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("goroutine 1 [running]:\n")
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&b, "%s(...)\n\t%s:%d +0x%x\n", frame.Function, frame.File, frame.Line, frame.PC-frame.Entry)
+		if !more {
+			return b.String()
+		}
+	}
+}
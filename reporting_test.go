@@ -0,0 +1,45 @@
+package errors_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleToReportedErrorEvent() {
+	errors.Configure(errors.Options{ServiceName: "user-service"})
+	defer errors.Configure(errors.Options{})
+
+	event := errors.ToReportedErrorEvent(errors.New("a"))
+	fmt.Println(event.ServiceContext.Service)
+	fmt.Println(event.Message)
+	fmt.Println(event.Context.ReportLocation.FunctionName)
+
+	// Output:
+	// user-service
+	// a
+	// github.com/bzon/errors_test.ExampleToReportedErrorEvent
+}
+
+func ExampleToReportedErrorEvent_panic() {
+	err := errors.Go(func() error {
+		doPanic()
+		return nil
+	})
+
+	event := errors.ToReportedErrorEvent(err)
+	lines := strings.Split(event.Message, "\n")
+	for _, i := range []int{0, 2, 3} {
+		if i < len(lines) {
+			fmt.Println(lines[i])
+		}
+	}
+	fmt.Println(event.Context == nil)
+
+	// Output:
+	// panic: boom
+	// goroutine 1 [running]:
+	// github.com/bzon/errors_test.doPanic(...)
+	// true
+}
@@ -0,0 +1,77 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+)
+
+// tristate is a bool that can be unset.
+type tristate int8
+
+const (
+	tristateUnset tristate = iota
+	tristateNo
+	tristateYes
+)
+
+func tristateOf(b bool) tristate {
+	if b {
+		return tristateYes
+	}
+	return tristateNo
+}
+
+// WithRetryable returns a copy of err that is marked as retryable or not, see IsRetryable.
+// If err is not created by this package, it is wrapped with the source location of the caller.
+func WithRetryable(err error, retryable bool) error {
+	if err == nil {
+		return nil
+	}
+	e := withContext(err, wrappedFunctionCallDepth)
+	e.retryable = tristateOf(retryable)
+	return e
+}
+
+// IsRetryable reports whether the operation that failed with err can be retried.
+// The mark of the outermost error in the chain of err that is marked with WithRetryable is used.
+// Otherwise err is classified:
+// context cancellation is not retryable, while deadlines, io.EOF, io.ErrUnexpectedEOF,
+// net.Error timeouts and the codes Unavailable, ResourceExhausted, Aborted and DeadlineExceeded are.
+// It returns false for a nil error.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	r := tristateUnset
+	visit(err, func(e *errorContext) bool {
+		r = e.retryable
+		return r != tristateUnset
+	})
+	if r != tristateUnset {
+		return r == tristateYes
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return false
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, io.EOF),
+		errors.Is(err, io.ErrUnexpectedEOF):
+		return true
+	}
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return true
+	}
+	return isRetryableCode(CodeOf(err))
+}
+
+// isRetryableCode reports whether the code c is of an error that can be retried.
+func isRetryableCode(c Code) bool {
+	switch c {
+	case Unavailable, ResourceExhausted, Aborted, DeadlineExceeded:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleWithRetryable() {
+	err := errors.WithRetryable(errors.New("lock held"), true)
+	err = errors.Wrap(err, "update user")
+	fmt.Println(errors.IsRetryable(err))
+	fmt.Println(errors.IsRetryable(errors.WithRetryable(io.EOF, false)))
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleIsRetryable() {
+	fmt.Println(errors.IsRetryable(errors.Wrap(context.DeadlineExceeded, "get user")))
+	fmt.Println(errors.IsRetryable(errors.Wrap(context.Canceled, "get user")))
+	fmt.Println(errors.IsRetryable(errors.Wrap(io.EOF, "read body")))
+	fmt.Println(errors.IsRetryable(errors.Wrap(&net.DNSError{IsTimeout: true}, "dial")))
+	fmt.Println(errors.IsRetryable(errors.WithCode(errors.New("overloaded"), errors.Unavailable)))
+	fmt.Println(errors.IsRetryable(errors.New("invalid email")))
+
+	// Output:
+	// true
+	// false
+	// true
+	// true
+	// true
+	// false
+}
@@ -0,0 +1,39 @@
+package errors
+
+import (
+	"context"
+	"errors"
+)
+
+// WithRollback runs work as a unit of work, e.g. a database transaction, and calls rollback when it fails.
+// The error of work, or its panic recovered like Recover, is wrapped with the source location of the caller
+// and annotated on the span of ctx, see WithContext.
+// The error of rollback, if any, is joined to it with the message "rollback".
+// It returns nil when work succeeds, without calling rollback.
+//
+//	tx, err := db.BeginTx(ctx, nil)
+//	if err != nil {
+//		return errors.Wrap(err, "begin")
+//	}
+//	return errors.WithRollback(ctx, func(ctx context.Context) error {
+//		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+//			return err
+//		}
+//		return tx.Commit()
+//	}, tx.Rollback)
+func WithRollback(ctx context.Context, work func(ctx context.Context) error, rollback func() error) error {
+	err := Go(func() error {
+		return work(ctx)
+	})
+	if err == nil {
+		return nil
+	}
+	var s settings
+	WithContext(ctx).apply(&s)
+	e := newErrorContext(err, captureLocation(wrappedFunctionCallDepth))
+	e.traceContext = s.traceContext
+	if rerr := rollback(); rerr != nil {
+		e.err = errors.Join(err, wrapMessage(rerr, "rollback"))
+	}
+	return created(e, s.annotator)
+}
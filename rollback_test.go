@@ -0,0 +1,34 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleWithRollback() {
+	rollback := func() error {
+		fmt.Println("rollback")
+		return fmt.Errorf("connection reset")
+	}
+	err := errors.WithRollback(context.Background(), func(ctx context.Context) error {
+		return errors.NewO("user not found", errors.NotFound)
+	}, rollback)
+	fmt.Println(err)
+	fmt.Println(errors.CodeOf(err))
+	fmt.Println(err.(errors.ErrorTracer).SourceLocation().Function)
+
+	err = errors.WithRollback(context.Background(), func(ctx context.Context) error {
+		return nil
+	}, rollback)
+	fmt.Println(err)
+
+	// Output:
+	// rollback
+	// user not found
+	// rollback: connection reset
+	// NOT_FOUND
+	// github.com/bzon/errors_test.ExampleWithRollback
+	// <nil>
+}
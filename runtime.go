@@ -0,0 +1,83 @@
+package errors
+
+import (
+	"bytes"
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+const logKeyRuntime = "runtime"
+
+// Runtime is the runtime metadata of the process and the goroutine where an error occurred,
+// captured when Options.CaptureRuntime is set.
+type Runtime struct {
+	GoroutineID uint64 `json:"goroutineId"`
+	GOOS        string `json:"goos"`
+	GOARCH      string `json:"goarch"`
+	GoVersion   string `json:"goVersion"`
+	Hostname    string `json:"hostname,omitempty"`
+	// Pod is the name of the Kubernetes pod, read from the POD_NAME environment variable,
+	// that is usually set with the downward API.
+	Pod string `json:"pod,omitempty"`
+}
+
+// IsZero reports whether r is the zero Runtime, of an error without runtime metadata.
+func (r Runtime) IsZero() bool {
+	return r == Runtime{}
+}
+
+var (
+	processRuntimeOnce sync.Once
+	processRuntime     Runtime
+)
+
+// captureRuntime returns the runtime metadata of the calling goroutine.
+// The metadata of the process is read once.
+func captureRuntime() *Runtime {
+	processRuntimeOnce.Do(func() {
+		host, _ := os.Hostname()
+		processRuntime = Runtime{
+			GOOS:      runtime.GOOS,
+			GOARCH:    runtime.GOARCH,
+			GoVersion: runtime.Version(),
+			Hostname:  host,
+			Pod:       os.Getenv("POD_NAME"),
+		}
+	})
+	r := processRuntime
+	r.GoroutineID = goroutineID()
+	return &r
+}
+
+// goroutineID returns the ID of the calling goroutine, read from the header of its stack trace,
+// e.g. goroutine 18 [running]:.
+// It returns 0 when the header cannot be parsed.
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i > 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
+
+// RuntimeOf returns the runtime metadata captured with err, if any, see Options.CaptureRuntime.
+// It is the metadata of the innermost error of this package in the chain of err, where the error occurred.
+func RuntimeOf(err error) Runtime {
+	if e := contextOf(err); e != nil {
+		return e.Runtime()
+	}
+	return Runtime{}
+}
+
+// Runtime returns the runtime metadata captured with the error, see RuntimeOf.
+func (e *errorContext) Runtime() Runtime {
+	if r := e.origin().runtime; r != nil {
+		return *r
+	}
+	return Runtime{}
+}
@@ -0,0 +1,33 @@
+package errors_test
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleOptions_captureRuntime() {
+	errors.Configure(errors.Options{CaptureRuntime: true})
+	defer errors.Configure(errors.Options{})
+
+	err := errors.Unmarshal(errors.Marshal(errors.Wrap(errors.New("a"), "b")))
+	e, _ := errors.TracerOf(err)
+	r := errors.RuntimeOf(e)
+	fmt.Println(r.GoroutineID > 0)
+	fmt.Println(r.GOOS == runtime.GOOS, r.GOARCH == runtime.GOARCH, r.GoVersion == runtime.Version())
+	fmt.Println(errors.LogFieldsMap(err)["runtime"] == r)
+
+	// Output:
+	// true
+	// true true true
+	// true
+}
+
+func ExampleRuntime_IsZero() {
+	e, _ := errors.TracerOf(errors.New("a"))
+	fmt.Println(errors.RuntimeOf(e).IsZero())
+
+	// Output:
+	// true
+}
@@ -0,0 +1,118 @@
+package errors
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// AnnotateLimit limits the errors of the same fingerprint annotated on spans, see Options.AnnotateLimit.
+// The first Burst errors of a fingerprint are annotated in every Interval, the others are only counted.
+// The first error annotated after some were skipped reports their number in its annotation message.
+type AnnotateLimit struct {
+	// Burst is the number of errors of a fingerprint annotated per Interval.
+	Burst int
+	// Interval is the duration of the window in which Burst errors of a fingerprint are annotated.
+	Interval time.Duration
+}
+
+// enabled reports whether l limits the annotations.
+func (l AnnotateLimit) enabled() bool {
+	return l.Burst > 0 && l.Interval > 0
+}
+
+// sampleWindow is the annotation window of a fingerprint.
+type sampleWindow struct {
+	start     time.Time
+	annotated int
+	// skipped is the number of errors skipped since the last annotated one.
+	skipped uint64
+	// total is the number of errors skipped since the sampler was reset.
+	total uint64
+}
+
+// maxSampleWindows caps the fingerprints tracked by the sampler, so that the errors with dynamic messages,
+// that have as many fingerprints as messages, do not grow it unbounded.
+// The errors of the fingerprints that are not tracked are annotated.
+const maxSampleWindows = 10000
+
+var (
+	samplerMu sync.Mutex
+	windows   = map[string]*sampleWindow{}
+	// lastSampleSweep is the time of the last sweep of the windows.
+	lastSampleSweep time.Time
+)
+
+// resetSampler forgets the annotation windows of all fingerprints.
+func resetSampler() {
+	samplerMu.Lock()
+	defer samplerMu.Unlock()
+	windows = map[string]*sampleWindow{}
+	lastSampleSweep = time.Time{}
+}
+
+// sweepWindows forgets the windows that are over and have nothing to report, at most once per interval.
+// The windows with skipped errors are kept for SkippedAnnotations and the next annotation of their fingerprint.
+func sweepWindows(l AnnotateLimit, now time.Time) {
+	if now.Sub(lastSampleSweep) < l.Interval {
+		return
+	}
+	lastSampleSweep = now
+	for fp, w := range windows {
+		if now.Sub(w.start) >= l.Interval && w.skipped == 0 && w.total == 0 {
+			delete(windows, fp)
+		}
+	}
+}
+
+// sample reports whether the error with fingerprint fp is annotated under l,
+// and the number of errors of fp skipped since the last annotated one.
+func sample(l AnnotateLimit, fp string, now time.Time) (bool, uint64) {
+	samplerMu.Lock()
+	defer samplerMu.Unlock()
+	w, ok := windows[fp]
+	if !ok {
+		sweepWindows(l, now)
+		if len(windows) >= maxSampleWindows {
+			return true, 0
+		}
+		w = &sampleWindow{start: now}
+		windows[fp] = w
+	}
+	if now.Sub(w.start) >= l.Interval {
+		w.start = now
+		w.annotated = 0
+	}
+	if w.annotated >= l.Burst {
+		w.skipped++
+		w.total++
+		return false, 0
+	}
+	w.annotated++
+	skipped := w.skipped
+	w.skipped = 0
+	return true, skipped
+}
+
+// SkippedAnnotations returns the number of errors that were not annotated on their span because of
+// Options.AnnotateLimit, by fingerprint, since the options were last configured.
+// It is meant to be exported as a metric.
+func SkippedAnnotations() map[string]uint64 {
+	samplerMu.Lock()
+	defer samplerMu.Unlock()
+	m := make(map[string]uint64, len(windows))
+	for fp, w := range windows {
+		if w.total > 0 {
+			m[fp] = w.total
+		}
+	}
+	return m
+}
+
+// skippedSuffix is appended to the annotation message of an error when n errors of its fingerprint were skipped.
+func skippedSuffix(n uint64) string {
+	if n == 0 {
+		return ""
+	}
+	return " (" + strconv.FormatUint(n, 10) + " similar errors not annotated)"
+}
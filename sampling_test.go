@@ -0,0 +1,28 @@
+package errors_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/errtest"
+)
+
+func ExampleAnnotateLimit() {
+	errors.Configure(errors.Options{
+		AnnotateLimit: errors.AnnotateLimit{Burst: 2, Interval: time.Hour},
+	})
+	defer errors.Configure(errors.Options{})
+
+	span := errtest.NewSpanRecorder()
+	for i := 0; i < 5; i++ {
+		_ = errors.NewO("connection refused", errors.Unavailable, span.Option())
+	}
+	fmt.Println(len(span.Annotations()))
+	for _, n := range errors.SkippedAnnotations() {
+		fmt.Println(n)
+	}
+	// Output:
+	// 2
+	// 3
+}
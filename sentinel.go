@@ -0,0 +1,49 @@
+package errors
+
+import (
+	"errors"
+	"time"
+)
+
+// Sentinel returns a sentinel error with the message m, meant for the package-level var declarations.
+// Unlike New, it records no source location, ID or time when the package is initialized,
+// and calls no hook.
+// The functions that return a copy of an error, e.g. WithCode, locate the copy of a sentinel error at their caller,
+// and Wrap locates its wrapping error at its caller, so that the errors report the site where the sentinel is used,
+// rather than the init of its package. The copies and the wrapping errors match the sentinel with Is.
+// The codes and fields of opts are set on the sentinel, its other options are ignored.
+//
+//	var ErrNotFound = errors.Sentinel("resource not found", errors.NotFound)
+//
+//	return errors.Wrap(ErrNotFound, "get user")
+func Sentinel(m string, opts ...Option) error {
+	var s settings
+	for _, opt := range opts {
+		opt.apply(&s)
+	}
+	return newContext(errorContext{
+		err:      errors.New(m),
+		code:     s.code,
+		fields:   s.fields,
+		sentinel: true,
+	})
+}
+
+// IsSentinel reports whether err is a sentinel error created by Sentinel, rather than a use of it.
+func IsSentinel(err error) bool {
+	e, ok := err.(*errorContext)
+	return ok && e.sentinel
+}
+
+// use returns a copy of the sentinel error e, located at loc, with a new ID and the current time.
+func (e *errorContext) use(loc location) *errorContext {
+	c := e.clone()
+	c.sentinel = false
+	c.sourceLocation = loc
+	c.id = nextID()
+	c.occurredAt = time.Now()
+	if !traceDisabled && config().CaptureRuntime {
+		c.runtime = captureRuntime()
+	}
+	return c
+}
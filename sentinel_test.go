@@ -0,0 +1,29 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+)
+
+var errResourceNotFound = errors.Sentinel("resource not found", errors.NotFound)
+
+func ExampleSentinel() {
+	err := errors.Wrap(errResourceNotFound, "get user")
+	fmt.Println(err, errors.CodeOf(err), errors.Is(err, errResourceNotFound))
+	for _, src := range errors.FramesOf(err) {
+		fmt.Println(src.Function)
+	}
+
+	err = errors.WithSeverity(errResourceNotFound, errors.SeverityWarning)
+	fmt.Println(err, errors.Is(err, errResourceNotFound), errors.IsSentinel(err))
+	fmt.Println(err.(errors.ErrorTracer).SourceLocation().Function)
+	fmt.Println(errResourceNotFound.(errors.ErrorTracer).SourceLocation().Function == "")
+
+	// Output:
+	// get user: resource not found NOT_FOUND true
+	// github.com/bzon/errors_test.ExampleSentinel
+	// resource not found true false
+	// github.com/bzon/errors_test.ExampleSentinel
+	// true
+}
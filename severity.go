@@ -0,0 +1,65 @@
+package errors
+
+import (
+	"strconv"
+)
+
+// Severity is the severity of an error.
+// The values are the same as the Stackdriver LogSeverity.
+// See https://cloud.google.com/logging/docs/reference/v2/rest/v2/LogEntry#LogSeverity.
+type Severity int
+
+// The severities.
+const (
+	SeverityDefault  Severity = 0
+	SeverityDebug    Severity = 100
+	SeverityInfo     Severity = 200
+	SeverityWarning  Severity = 400
+	SeverityError    Severity = 500
+	SeverityCritical Severity = 600
+)
+
+var severityNames = map[Severity]string{
+	SeverityDefault:  "DEFAULT",
+	SeverityDebug:    "DEBUG",
+	SeverityInfo:     "INFO",
+	SeverityWarning:  "WARNING",
+	SeverityError:    "ERROR",
+	SeverityCritical: "CRITICAL",
+}
+
+// String returns the Stackdriver name of the severity, e.g. ERROR.
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return "SEVERITY(" + strconv.Itoa(int(s)) + ")"
+}
+
+// WithSeverity returns a copy of err that has the severity s.
+// If err is not created by this package, it is wrapped with the source location of the caller.
+func WithSeverity(err error, s Severity) error {
+	if err == nil {
+		return nil
+	}
+	e := withContext(err, wrappedFunctionCallDepth)
+	e.severity = s
+	return e
+}
+
+// SeverityOf returns the severity of the outermost error in the chain of err that has one.
+// It returns SeverityDefault for a nil error and SeverityError when no severity is found.
+func SeverityOf(err error) Severity {
+	if err == nil {
+		return SeverityDefault
+	}
+	s := SeverityError
+	visit(err, func(e *errorContext) bool {
+		if e.severity != SeverityDefault {
+			s = e.severity
+			return true
+		}
+		return false
+	})
+	return s
+}
@@ -0,0 +1,20 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleWithSeverity() {
+	err := errors.WithSeverity(errors.New("cache miss"), errors.SeverityDebug)
+	err = errors.Wrap(err, "get user")
+	fmt.Println(errors.SeverityOf(err))
+	fmt.Println(errors.SeverityOf(errors.New("a")))
+	fmt.Println(errors.LogFieldsMap(err)["severity"])
+
+	// Output:
+	// DEBUG
+	// ERROR
+	// DEBUG
+}
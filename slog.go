@@ -0,0 +1,41 @@
+package errors
+
+import (
+	"log/slog"
+)
+
+// Compile time implementation check.
+var _ slog.LogValuer = &errorContext{}
+
+// LogValue implements slog.LogValuer.
+// The error is logged as a group with its message, scrubbed by the redactors, ID, source location and trace context.
+func (e *errorContext) LogValue() slog.Value {
+	AnnotateDeferred(e)
+	attrs := []slog.Attr{
+		slog.String("message", RedactedMessage(e)),
+		slog.String("id", e.ID()),
+		slog.Any("sourceLocation", e.SourceLocation()),
+	}
+	if tc := e.TraceContext(); tc.TraceID != "" {
+		attrs = append(attrs,
+			slog.String("trace", tc.TraceID),
+			slog.String("spanId", tc.SpanID),
+		)
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// SlogAttrs returns LogFields as slog attributes.
+//
+//	logger.LogAttrs(ctx, slog.LevelError, err.Error(), errors.SlogAttrs(err)...)
+func SlogAttrs(err error) []slog.Attr {
+	fields := LogFields(err)
+	if fields == nil {
+		return nil
+	}
+	attrs := make([]slog.Attr, 0, len(fields)/2)
+	for i := 0; i < len(fields); i += 2 {
+		attrs = append(attrs, slog.Any(fields[i].(string), fields[i+1]))
+	}
+	return attrs
+}
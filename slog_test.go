@@ -0,0 +1,48 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/bzon/errors"
+	"go.opencensus.io/trace"
+)
+
+func ExampleSlogAttrs() {
+	_, span := trace.StartSpan(context.Background(), "foo")
+	defer span.End()
+
+	err := errors.NewT(span, "a")
+	for _, attr := range errors.SlogAttrs(err) {
+		fmt.Println(attr.Key)
+	}
+
+	// Output:
+	// severity
+	// logging.googleapis.com/sourceLocation
+	// logging.googleapis.com/trace
+	// logging.googleapis.com/spanId
+	// logging.googleapis.com/trace_sampled
+	// fingerprint
+	// errorId
+}
+
+func Example_slog() {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			// Keep the output stable.
+			if a.Key == slog.TimeKey || a.Key == "sourceLocation" || a.Key == "id" {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	err := errors.Wrap(errors.New("a"), "b")
+	logger.Error("failed", "error", err)
+
+	// Output:
+	// {"level":"ERROR","msg":"failed","error":{"message":"b: a"}}
+}
@@ -0,0 +1,71 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// sourceFiles caches the lines of the source files read by snippet.
+var sourceFiles sync.Map
+
+// SnippetOf returns the lines of source code around the source location of the outermost error
+// of this package in the chain of err, read from the local source file, see Options.SnippetLines.
+func SnippetOf(err error) string {
+	if e := contextOf(err); e != nil {
+		return e.Snippet()
+	}
+	return ""
+}
+
+// Snippet returns the lines of source code around the source location of the error, see SnippetOf.
+func (e *errorContext) Snippet() string {
+	return snippet(e.SourceLocation())
+}
+
+// snippet returns the Options.SnippetLines lines before and after the line of src, numbered,
+// with the line of src marked with >.
+// It returns an empty string when Options.SnippetLines is 0 or the file cannot be read.
+func snippet(src SourceLocation) string {
+	n := config().SnippetLines
+	if n <= 0 || src.File == "" || src.Line <= 0 {
+		return ""
+	}
+	lines := sourceLines(src.File)
+	if src.Line > len(lines) {
+		return ""
+	}
+	first, last := src.Line-n, src.Line+n
+	if first < 1 {
+		first = 1
+	}
+	if last > len(lines) {
+		last = len(lines)
+	}
+	width := len(fmt.Sprint(last))
+	var b strings.Builder
+	for i := first; i <= last; i++ {
+		marker := " "
+		if i == src.Line {
+			marker = ">"
+		}
+		fmt.Fprintf(&b, "%s %*d | %s\n", marker, width, i, lines[i-1])
+	}
+	return b.String()
+}
+
+// sourceLines returns the lines of file, or nil if it cannot be read.
+func sourceLines(file string) []string {
+	if v, ok := sourceFiles.Load(file); ok {
+		return v.([]string)
+	}
+	b, err := os.ReadFile(file)
+	var lines []string
+	if err == nil {
+		lines = strings.Split(string(bytes.TrimSuffix(b, []byte("\n"))), "\n")
+	}
+	sourceFiles.Store(file, lines)
+	return lines
+}
@@ -0,0 +1,29 @@
+package errors_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bzon/errors"
+)
+
+func openConfig() error {
+	return errors.New("config not found")
+}
+
+func ExampleOptions_snippetLines() {
+	errors.Configure(errors.Options{SnippetLines: 1})
+	defer errors.Configure(errors.Options{})
+
+	err := openConfig()
+	fmt.Print(errors.SnippetOf(err))
+
+	out := fmt.Sprintf("%+v", err)
+	fmt.Println(strings.Contains(out, "> 11 | \treturn errors.New"))
+
+	// Output:
+	//   10 | func openConfig() error {
+	// > 11 | 	return errors.New("config not found")
+	//   12 | }
+	// true
+}
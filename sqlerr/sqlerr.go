@@ -0,0 +1,152 @@
+// Package sqlerr wraps database/sql errors with their operation, table and code.
+//
+//	row := db.QueryRowContext(ctx, query, id)
+//	if err := row.Scan(&u.Name); err != nil {
+//		return sqlerr.Wrap(ctx, err, query)
+//	}
+package sqlerr
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strings"
+
+	"github.com/bzon/errors"
+)
+
+// Field keys of the wrapped errors, from the OpenTelemetry database semantic conventions.
+const (
+	KeyOperation = "db.operation"
+	KeyTable     = "db.sql.table"
+)
+
+// Wrap wraps err of the query with its operation and table, e.g. "SELECT users", and its code, see Code.
+// The query itself is not part of the error, it may hold personal data.
+// The error is annotated on the span of ctx, tagged with the op sql.<operation>,
+// and has the operation and table as fields.
+// It returns nil if err is nil.
+func Wrap(ctx context.Context, err error, query string) error {
+	if err == nil {
+		return nil
+	}
+	op, table := parseQuery(query)
+	m := strings.TrimSpace(op + " " + table)
+	if m == "" {
+		m = "sql"
+	}
+	fields := errors.Fields{}
+	if op != "" {
+		fields[KeyOperation] = op
+	}
+	if table != "" {
+		fields[KeyTable] = table
+	}
+	err = errors.WrapO(err, m,
+		Code(err),
+		errors.WithContext(ctx),
+		errors.WithFields(fields),
+		// Skip Wrap.
+		errors.WithDepth(3),
+	)
+	if op == "" {
+		return err
+	}
+	return errors.WithOp(err, "sql."+strings.ToLower(op))
+}
+
+// Code returns the code of a database/sql or driver error:
+//   - NotFound for sql.ErrNoRows.
+//   - AlreadyExists for unique constraint violations.
+//   - FailedPrecondition for foreign key constraint violations.
+//   - InvalidArgument for not null and check constraint violations.
+//   - Canceled and DeadlineExceeded for context errors.
+//   - The code of err, if any, or else Unknown.
+//
+// The constraint violations are detected from the SQLSTATE of the PostgreSQL drivers, pgx and lib/pq,
+// and from the messages of the MySQL and SQLite drivers.
+func Code(err error) errors.Code {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return errors.NotFound
+	case errors.Is(err, context.Canceled):
+		return errors.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return errors.DeadlineExceeded
+	}
+	if c, ok := constraintCode(err); ok {
+		return c
+	}
+	return errors.CodeOf(err)
+}
+
+// SQLSTATE integrity constraint violations.
+// See https://www.postgresql.org/docs/current/errcodes-appendix.html.
+var sqlStateCodes = map[string]errors.Code{
+	"23502": errors.InvalidArgument,    // not_null_violation
+	"23503": errors.FailedPrecondition, // foreign_key_violation
+	"23505": errors.AlreadyExists,      // unique_violation
+	"23514": errors.InvalidArgument,    // check_violation
+}
+
+// MySQL server error numbers.
+// See https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html.
+var mysqlCodes = map[string]errors.Code{
+	"1048": errors.InvalidArgument,    // ER_BAD_NULL_ERROR
+	"1062": errors.AlreadyExists,      // ER_DUP_ENTRY
+	"1451": errors.FailedPrecondition, // ER_ROW_IS_REFERENCED_2
+	"1452": errors.FailedPrecondition, // ER_NO_REFERENCED_ROW_2
+	"3819": errors.InvalidArgument,    // ER_CHECK_CONSTRAINT_VIOLATED
+}
+
+// SQLite constraint messages.
+// See https://www.sqlite.org/rescode.html#constraint.
+var sqliteCodes = map[string]errors.Code{
+	"NOT NULL constraint failed":    errors.InvalidArgument,
+	"FOREIGN KEY constraint failed": errors.FailedPrecondition,
+	"UNIQUE constraint failed":      errors.AlreadyExists,
+	"CHECK constraint failed":       errors.InvalidArgument,
+}
+
+var mysqlError = regexp.MustCompile(`^Error (\d+)`)
+
+func constraintCode(err error) (errors.Code, bool) {
+	var state interface{ SQLState() string }
+	if errors.As(err, &state) {
+		c, ok := sqlStateCodes[state.SQLState()]
+		return c, ok
+	}
+	var found bool
+	var c errors.Code
+	errors.Walk(err, func(err error) bool {
+		msg := err.Error()
+		if m := mysqlError.FindStringSubmatch(msg); m != nil {
+			c, found = mysqlCodes[m[1]]
+			return false
+		}
+		for prefix, code := range sqliteCodes {
+			if strings.Contains(msg, prefix) {
+				c, found = code, true
+				return false
+			}
+		}
+		return true
+	})
+	return c, found
+}
+
+var (
+	queryOperation = regexp.MustCompile(`^\s*(?i)(SELECT|INSERT|UPDATE|DELETE|UPSERT|REPLACE|MERGE|CREATE|ALTER|DROP|TRUNCATE)\b`)
+	queryTable     = regexp.MustCompile(`(?i)\b(?:FROM|INTO|UPDATE|TABLE|JOIN)\s+([\w."` + "`" + `]+)`)
+)
+
+// parseQuery returns the upper case operation and the first table of query, if any.
+func parseQuery(query string) (op, table string) {
+	if m := queryOperation.FindStringSubmatch(query); m != nil {
+		op = strings.ToUpper(m[1])
+	}
+	if m := queryTable.FindStringSubmatch(query); m != nil {
+		table = strings.Trim(m[1], "\"`")
+	}
+	return op, table
+}
@@ -0,0 +1,51 @@
+package sqlerr_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/sqlerr"
+)
+
+func ExampleWrap() {
+	query := "SELECT name FROM users WHERE id = $1"
+	err := sqlerr.Wrap(context.Background(), sql.ErrNoRows, query)
+
+	fmt.Println(err)
+	fmt.Println(errors.CodeOf(err))
+	fmt.Println(errors.Ops(err))
+	fmt.Println(errors.FieldsOf(err))
+	fmt.Println(err.(errors.ErrorTracer).SourceLocation().Function)
+
+	// Output:
+	// SELECT users: sql: no rows in result set
+	// NOT_FOUND
+	// [sql.select]
+	// map[db.operation:SELECT db.sql.table:users]
+	// github.com/bzon/errors/sqlerr_test.ExampleWrap
+}
+
+// pgError is like the errors of the pgx and lib/pq drivers.
+type pgError struct {
+	code string
+}
+
+func (e *pgError) Error() string    { return "duplicate key value violates unique constraint" }
+func (e *pgError) SQLState() string { return e.code }
+
+func ExampleCode() {
+	fmt.Println(sqlerr.Code(&pgError{code: "23505"}))
+	fmt.Println(sqlerr.Code(fmt.Errorf("insert: %w", &pgError{code: "23503"})))
+	fmt.Println(sqlerr.Code(fmt.Errorf("Error 1062 (23000): Duplicate entry 'a' for key 'users.name'")))
+	fmt.Println(sqlerr.Code(fmt.Errorf("NOT NULL constraint failed: users.name")))
+	fmt.Println(sqlerr.Code(fmt.Errorf("connection refused")))
+
+	// Output:
+	// ALREADY_EXISTS
+	// FAILED_PRECONDITION
+	// ALREADY_EXISTS
+	// INVALID_ARGUMENT
+	// UNKNOWN
+}
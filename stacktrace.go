@@ -0,0 +1,65 @@
+package errors
+
+import pkgerrors "github.com/pkg/errors"
+
+// Frame is a program counter of a call stack, the Frame of github.com/pkg/errors.
+type Frame = pkgerrors.Frame
+
+// StackTrace is a call stack, innermost first, the StackTrace of github.com/pkg/errors.
+// Error trackers that extract the frames of github.com/pkg/errors errors,
+// e.g. sentry-go and Elastic APM, extract the frames of the errors of this package as well.
+type StackTrace = pkgerrors.StackTrace
+
+// StackTraceOf returns the stack trace of the outermost error of this package in the chain of err, or nil,
+// see the StackTrace method of the errors.
+func StackTraceOf(err error) StackTrace {
+	if e := contextOf(err); e != nil {
+		return e.StackTrace()
+	}
+	return nil
+}
+
+// StackTrace returns the stack captured with the error, if any, otherwise the source locations
+// of the layers of the error chain, innermost first, like github.com/pkg/errors does.
+// It implements the stackTracer interface of github.com/pkg/errors.
+// The source locations of errors of other processes are left out.
+func (e *errorContext) StackTrace() StackTrace {
+	if len(e.stack) > 0 {
+		st := make(StackTrace, len(e.stack))
+		for i, pc := range e.stack {
+			st[i] = Frame(pc)
+		}
+		return st
+	}
+	var st StackTrace
+	visit(e, func(c *errorContext) bool {
+		if loc := c.location(); loc.pc != 0 {
+			st = append(st, Frame(loc.pc))
+		}
+		return false
+	})
+	// The chain is visited outermost first.
+	for i, j := 0, len(st)-1; i < j; i, j = i+1, j-1 {
+		st[i], st[j] = st[j], st[i]
+	}
+	return st
+}
+
+// WithStack returns err with the source location of the caller and the stack of the calling goroutine,
+// like WithStack of github.com/pkg/errors.
+// The message of err is unchanged.
+// It returns nil if err is nil.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	return created(withStack(err), nil)
+}
+
+func withStack(err error) *errorContext {
+	// Skip withStack.
+	e := newErrorContext(err, captureLocation(wrappedFunctionCallDepth+1))
+	// Skip callers, withStack and WithStack.
+	e.setStack(callers(3))
+	return e
+}
@@ -0,0 +1,57 @@
+//go:build !tracedisabled
+
+package errors_test
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleStackTrace() {
+	err := errors.New("a")
+	err = errors.Wrap(err, "b")
+
+	// The interface of github.com/pkg/errors.
+	type stackTracer interface {
+		StackTrace() errors.StackTrace
+	}
+	if e, ok := err.(stackTracer); ok {
+		st := e.StackTrace()
+		fmt.Println(len(st))
+		fmt.Printf("%n %s\n", st[0], st[0])
+	}
+
+	// Output:
+	// 2
+	// ExampleStackTrace stacktrace_test.go
+}
+
+func ExampleStackTrace_recover() {
+	err := errors.Go(func() error {
+		panic("boom")
+	})
+
+	st := errors.StackTraceOf(err)
+	fmt.Printf("%n\n", st[0])
+
+	// Output:
+	// ExampleStackTrace_recover.func1
+}
+
+func ExampleWithStack() {
+	err := errors.WithStack(io.ErrUnexpectedEOF)
+
+	e := err.(errors.ErrorTracer)
+	fmt.Println(err)
+	fmt.Println(e.SourceLocation().Function)
+	fmt.Println(errors.StackOf(e)[0].Function)
+	fmt.Println(errors.Is(err, io.ErrUnexpectedEOF))
+
+	// Output:
+	// unexpected EOF
+	// github.com/bzon/errors_test.ExampleWithStack
+	// github.com/bzon/errors_test.ExampleWithStack
+	// true
+}
@@ -0,0 +1,71 @@
+package errors
+
+// Compile time implementation checks.
+var (
+	_ interface{ Timeout() bool }   = &errorContext{}
+	_ interface{ Temporary() bool } = &errorContext{}
+)
+
+// WithTimeout returns a copy of err that is marked as a timeout or not, see the Timeout method.
+// If err is not created by this package, it is wrapped with the source location of the caller.
+func WithTimeout(err error, timeout bool) error {
+	if err == nil {
+		return nil
+	}
+	e := withContext(err, wrappedFunctionCallDepth)
+	e.timeout = tristateOf(timeout)
+	return e
+}
+
+// WithTemporary returns a copy of err that is marked as temporary or not, see the Temporary method.
+// If err is not created by this package, it is wrapped with the source location of the caller.
+func WithTemporary(err error, temporary bool) error {
+	if err == nil {
+		return nil
+	}
+	e := withContext(err, wrappedFunctionCallDepth)
+	e.temporary = tristateOf(temporary)
+	return e
+}
+
+// Timeout reports whether the error is a timeout, like net.Error does.
+// The mark of the outermost error in the chain that is marked with WithTimeout,
+// or else the first cause that implements Timeout() bool, is used.
+// Otherwise, the error is a timeout when its code is DeadlineExceeded.
+func (e *errorContext) Timeout() bool {
+	t := tristateUnset
+	walk(e, func(err error) bool {
+		switch err := err.(type) {
+		case *errorContext:
+			t = err.timeout
+		case interface{ Timeout() bool }:
+			t = tristateOf(err.Timeout())
+		}
+		return t != tristateUnset
+	})
+	if t != tristateUnset {
+		return t == tristateYes
+	}
+	return CodeOf(e) == DeadlineExceeded
+}
+
+// Temporary reports whether the error is temporary, like net.Error does.
+// The mark of the outermost error in the chain that is marked with WithTemporary,
+// or else the first cause that implements Temporary() bool, is used.
+// Otherwise, the error is temporary when its code is of an error that can be retried, see IsRetryable.
+func (e *errorContext) Temporary() bool {
+	t := tristateUnset
+	walk(e, func(err error) bool {
+		switch err := err.(type) {
+		case *errorContext:
+			t = err.temporary
+		case interface{ Temporary() bool }:
+			t = tristateOf(err.Temporary())
+		}
+		return t != tristateUnset
+	})
+	if t != tristateUnset {
+		return t == tristateYes
+	}
+	return isRetryableCode(CodeOf(e))
+}
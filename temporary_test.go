@@ -0,0 +1,47 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleWithTimeout() {
+	err := errors.WithTimeout(errors.New("no reply"), true)
+	err = errors.Wrap(err, "call user service")
+
+	var t interface{ Timeout() bool }
+	fmt.Println(errors.As(err, &t) && t.Timeout())
+
+	// Output:
+	// true
+}
+
+func ExampleWithTemporary() {
+	err := errors.WithTemporary(errors.New("too many open files"), true)
+
+	var t interface{ Temporary() bool }
+	fmt.Println(errors.As(err, &t) && t.Temporary())
+
+	// Output:
+	// true
+}
+
+func Example_timeout() {
+	// The cause decides when the error is not marked.
+	var ne net.Error
+	err := errors.Wrap(context.DeadlineExceeded, "get user")
+	fmt.Println(errors.As(err, &ne) && ne.Timeout())
+	err = errors.Wrap(&net.DNSError{IsTemporary: true}, "dial")
+	fmt.Println(errors.As(err, &ne) && ne.Timeout(), ne.Temporary())
+	// The code decides when neither is.
+	err = errors.WithCode(errors.New("overloaded"), errors.Unavailable)
+	fmt.Println(errors.As(err, &ne) && ne.Timeout(), ne.Temporary())
+
+	// Output:
+	// true
+	// false true
+	// false true
+}
@@ -0,0 +1,45 @@
+package errors
+
+import "context"
+
+type traceContextKey struct{}
+
+// ContextWithTraceContext returns a copy of ctx that carries tc, for the errors created with WithContext
+// in code that has a context but no span, e.g. a library called by a handler of a traced request.
+func ContextWithTraceContext(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, tc)
+}
+
+// ContextWithTraceParent returns a copy of ctx that carries the trace context of a W3C traceparent header,
+// see ContextWithTraceContext and ParseTraceParent.
+// It returns ctx as is when the header is invalid.
+func ContextWithTraceParent(ctx context.Context, header string) context.Context {
+	sc, err := ParseTraceParent(header)
+	if err != nil {
+		return ctx
+	}
+	return ContextWithTraceContext(ctx, newTraceContext(sc))
+}
+
+// TraceContextFromContext returns the trace context of the OpenCensus span of ctx,
+// or else the trace context carried by ctx, see ContextWithTraceContext.
+// It reports false when ctx has neither.
+func TraceContextFromContext(ctx context.Context) (TraceContext, bool) {
+	if a := contextSpan(ctx); a != nil {
+		return a.TraceContext(), true
+	}
+	tc, ok := ctx.Value(traceContextKey{}).(TraceContext)
+	return tc, ok && tc.TraceID != ""
+}
+
+// WithContext annotates the error on the OpenCensus span of ctx, like WithSpan, or else sets the trace context
+// carried by ctx, see ContextWithTraceContext, like WithTraceContext.
+//
+//	err := errors.NewO("user not found", errors.NotFound, errors.WithContext(ctx))
+func WithContext(ctx context.Context) Option {
+	if a := contextSpan(ctx); a != nil {
+		return WithSpanAnnotator(a)
+	}
+	tc, _ := ctx.Value(traceContextKey{}).(TraceContext)
+	return WithTraceContext(tc)
+}
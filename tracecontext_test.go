@@ -0,0 +1,34 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleWithContext() {
+	ctx := errors.ContextWithTraceParent(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	err := errors.NewO("user not found", errors.NotFound, errors.WithContext(ctx))
+	fmt.Println(err.(errors.ErrorTracer).TraceContext().TraceParent())
+
+	// Output:
+	// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+}
+
+func ExampleTraceContextFromContext() {
+	_, ok := errors.TraceContextFromContext(context.Background())
+	fmt.Println(ok)
+
+	ctx := errors.ContextWithTraceContext(context.Background(), errors.TraceContext{
+		TraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:  "00f067aa0ba902b7",
+	})
+	tc, ok := errors.TraceContextFromContext(ctx)
+	fmt.Println(tc.TraceID, ok)
+
+	// Output:
+	// false
+	// 4bf92f3577b34da6a3ce929d0e0e4736 true
+}
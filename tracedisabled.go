@@ -0,0 +1,13 @@
+//go:build tracedisabled
+
+package errors
+
+// traceDisabled is set by the tracedisabled build tag.
+// The constructors then create errors without source locations, stacks, trace contexts nor span annotations,
+// and do not call the hooks, for latency-critical binaries and benchmark comparisons:
+//
+//	go build -tags tracedisabled
+//
+// The errors are still ErrorTracer errors, with their codes, fields and the other attributes,
+// so that the code that uses them does not change.
+const traceDisabled = true
@@ -0,0 +1,35 @@
+//go:build tracedisabled
+
+package errors_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bzon/errors"
+	"go.opencensus.io/trace"
+)
+
+// Run with go test -tags tracedisabled -run TestTraceDisabled.
+func TestTraceDisabled(t *testing.T) {
+	_, span := trace.StartSpan(context.Background(), "op", trace.WithSampler(trace.AlwaysSample()))
+	defer span.End()
+
+	err := errors.WithStackT(span, errors.NewO("a", errors.NotFound))
+	e, ok := errors.TracerOf(err)
+	if !ok {
+		t.Fatalf("%v is not an ErrorTracer", err)
+	}
+	if got := e.SourceLocation().Function; got != "" {
+		t.Errorf("function = %q, want none", got)
+	}
+	if got := errors.StackOf(e); len(got) != 0 {
+		t.Errorf("stack = %v, want none", got)
+	}
+	if got := e.TraceContext(); got != (errors.TraceContext{}) {
+		t.Errorf("trace context = %v, want none", got)
+	}
+	if got := errors.CodeOf(err); got != errors.NotFound {
+		t.Errorf("code = %v, want %v", got, errors.NotFound)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !tracedisabled
+
+package errors
+
+// traceDisabled is set by the tracedisabled build tag, see tracedisabled.go.
+const traceDisabled = false
@@ -0,0 +1,73 @@
+package errors
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+const traceParentVersion = "00"
+
+// TraceParent returns the trace context in the W3C traceparent header format,
+// e.g. 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01.
+// It returns an empty string when there is no trace context.
+// See https://www.w3.org/TR/trace-context/#traceparent-header.
+func (t TraceContext) TraceParent() string {
+	if t.TraceID == "" || t.SpanID == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s-%s-%s-%02x", traceParentVersion, t.TraceID, t.SpanID, t.TraceFlags)
+}
+
+// Sampled reports whether the sampled flag of the W3C trace flags is set,
+// i.e. whether the span is exported to the tracing backend.
+func (t TraceContext) Sampled() bool {
+	return t.TraceFlags&1 == 1
+}
+
+// ParseTraceParent parses a W3C traceparent header into a SpanContext.
+func ParseTraceParent(header string) (SpanContext, error) {
+	var (
+		sc      SpanContext
+		traceID [16]byte
+		spanID  [8]byte
+		flags   [1]byte
+	)
+	// Future versions may append fields, version 00 has exactly 55 characters.
+	if len(header) < 55 || (len(header) > 55 && header[55] != '-') {
+		return sc, fmt.Errorf("invalid traceparent %q", header)
+	}
+	if header[2] != '-' || header[35] != '-' || header[52] != '-' {
+		return sc, fmt.Errorf("invalid traceparent %q", header)
+	}
+	version, err := hex.DecodeString(header[:2])
+	if err != nil || version[0] == 0xff || (version[0] == 0 && len(header) != 55) {
+		return sc, fmt.Errorf("invalid traceparent version %q", header[:2])
+	}
+	if _, err := hex.Decode(traceID[:], []byte(header[3:35])); err != nil || traceID == [16]byte{} {
+		return sc, fmt.Errorf("invalid traceparent trace-id %q", header[3:35])
+	}
+	if _, err := hex.Decode(spanID[:], []byte(header[36:52])); err != nil || spanID == [8]byte{} {
+		return sc, fmt.Errorf("invalid traceparent parent-id %q", header[36:52])
+	}
+	if _, err := hex.Decode(flags[:], []byte(header[53:55])); err != nil {
+		return sc, fmt.Errorf("invalid traceparent trace-flags %q", header[53:55])
+	}
+	return newSpanContext(traceID, spanID, flags[0]), nil
+}
+
+// SetTraceContextFromTraceParent sets the trace context of the outermost ErrorTracer in the chain of err
+// from a W3C traceparent header.
+// It returns an error if the header is invalid or err has no ErrorTracer.
+// Like SetTraceContext, it modifies err in place, see WithSpanContext and ParseTraceParent for a copy.
+func SetTraceContextFromTraceParent(err error, header string) error {
+	sc, perr := ParseTraceParent(header)
+	if perr != nil {
+		return perr
+	}
+	var e ErrorTracer
+	if !As(err, &e) {
+		return fmt.Errorf("%v is not an ErrorTracer", err)
+	}
+	e.SetTraceContext(sc)
+	return nil
+}
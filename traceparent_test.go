@@ -0,0 +1,55 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bzon/errors"
+	"go.opencensus.io/trace"
+)
+
+func ExampleTraceContext_TraceParent() {
+	err := errors.New("a")
+	e := err.(errors.ErrorTracer)
+	e.SetTraceContext(trace.SpanContext{
+		TraceID:      [16]byte{'a', 'b', 'c'},
+		SpanID:       [8]byte{'d', 'e', 'f'},
+		TraceOptions: 1,
+	})
+	fmt.Println(e.TraceContext().TraceParent())
+
+	// Output:
+	// 00-61626300000000000000000000000000-6465660000000000-01
+}
+
+func ExampleTraceContext_Sampled() {
+	// The errors are not annotated on the spans that are not sampled, but still carry their trace context.
+	_, span := trace.StartSpan(context.Background(), "op", trace.WithSampler(trace.NeverSample()))
+	defer span.End()
+
+	err := errors.NewT(span, "a")
+	tc := err.(errors.ErrorTracer).TraceContext()
+	fmt.Println(tc.TraceID != "", tc.Sampled())
+
+	// Output:
+	// true false
+}
+
+func ExampleSetTraceContextFromTraceParent() {
+	err := errors.New("a")
+	if perr := errors.SetTraceContextFromTraceParent(err, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"); perr != nil {
+		fmt.Println(perr)
+	}
+	e := err.(errors.ErrorTracer)
+	fmt.Println(e.TraceContext().TraceID)
+	fmt.Println(e.TraceContext().SpanID)
+	fmt.Println(e.TraceContext().TraceFlags)
+
+	fmt.Println(errors.SetTraceContextFromTraceParent(err, "00-00000000000000000000000000000000-00f067aa0ba902b7-01"))
+
+	// Output:
+	// 4bf92f3577b34da6a3ce929d0e0e4736
+	// 00f067aa0ba902b7
+	// 1
+	// invalid traceparent trace-id "00000000000000000000000000000000"
+}
@@ -0,0 +1,101 @@
+package errors
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrorTree is the graph of an error and its causes, see TreeOf.
+// It is encoded as JSON by encoding/json, e.g. for debug endpoints.
+type ErrorTree struct {
+	Message string `json:"message"`
+	// Code is the name of the code of the error, e.g. NOT_FOUND, when it has one.
+	Code           string          `json:"code,omitempty"`
+	SourceLocation *SourceLocation `json:"sourceLocation,omitempty"`
+	// Causes are the errors wrapped by the error. Multi-errors have more than one.
+	Causes []*ErrorTree `json:"causes,omitempty"`
+}
+
+// TreeOf returns the graph of err, its chain of wrapped errors and the branches of its multi-errors.
+// The ErrorTracer errors of the chain are not nodes of their own:
+// their code and source location are set on the node of the error they carry.
+// The errors deeper than Options.MaxChainDepth wraps, or that loop back to an error of their chain,
+// are replaced by a [chain truncated] node.
+// It returns nil for a nil error.
+func TreeOf(err error) *ErrorTree {
+	return treeOf(err, maxChainDepth(), &chainCycle{})
+}
+
+// treeOf is TreeOf down to depth wraps. The cycle detects the cycles of the chain of the branch of err.
+func treeOf(err error, depth int, cycle *chainCycle) *ErrorTree {
+	if err == nil {
+		return nil
+	}
+	if depth <= 0 || cycle.seen(err) {
+		return &ErrorTree{Message: chainTruncated}
+	}
+	if e, ok := err.(*errorContext); ok {
+		t := treeOf(e.err, depth, cycle)
+		if t.Code == "" && e.code != OK {
+			t.Code = e.code.String()
+		}
+		if src := e.SourceLocation(); t.SourceLocation == nil && src.Function != "" {
+			t.SourceLocation = &src
+		}
+		return t
+	}
+	t := &ErrorTree{Message: err.Error()}
+	if m, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, cause := range m.Unwrap() {
+			if cause != nil {
+				t.Causes = append(t.Causes, treeOf(cause, depth-1, &chainCycle{}))
+			}
+		}
+	} else if cause := errors.Unwrap(err); cause != nil {
+		t.Causes = []*ErrorTree{treeOf(cause, depth-1, cycle)}
+	}
+	return t
+}
+
+// Tree renders the graph of err, see TreeOf, one error per line with its code and source location,
+// and its causes indented below it, e.g.
+//
+//	get user: not found [NOT_FOUND] at main.getUser (main.go:42)
+//	└── not found [NOT_FOUND] at main.findUser (main.go:12)
+//
+// It returns an empty string for a nil error.
+func Tree(err error) string {
+	return TreeOf(err).String()
+}
+
+// String renders t like Tree.
+func (t *ErrorTree) String() string {
+	if t == nil {
+		return ""
+	}
+	var b strings.Builder
+	t.render(&b, "", "")
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// render writes the line of t, prefixed by first, and its causes, prefixed by indent.
+func (t *ErrorTree) render(b *strings.Builder, first, indent string) {
+	b.WriteString(first)
+	// A multiline message is kept on the line of its node.
+	b.WriteString(strings.ReplaceAll(t.Message, "\n", `\n`))
+	if t.Code != "" {
+		b.WriteString(" [" + t.Code + "]")
+	}
+	if src := t.SourceLocation; src != nil {
+		b.WriteString(" at " + src.Function + " (" + src.File + ":" + strconv.Itoa(src.Line) + ")")
+	}
+	b.WriteByte('\n')
+	for i, cause := range t.Causes {
+		if i == len(t.Causes)-1 {
+			cause.render(b, indent+"└── ", indent+"    ")
+		} else {
+			cause.render(b, indent+"├── ", indent+"│   ")
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleTree() {
+	errors.Configure(errors.Options{ModuleRelativePaths: true})
+	defer errors.Configure(errors.Options{})
+
+	notFound := errors.NewO("user not found", errors.NotFound,
+		errors.WithSourceLocation(errors.SourceLocation{Function: "main.findUser", File: "user.go", Line: 12}))
+	err := errors.Join(
+		errors.Wrap(notFound, "get user"),
+		fmt.Errorf("read config: %w", io.ErrUnexpectedEOF),
+	)
+	err = errors.WrapO(err, "load", errors.Internal,
+		errors.WithSourceLocation(errors.SourceLocation{Function: "main.load", File: "main.go", Line: 42}))
+	fmt.Println(errors.Tree(err))
+
+	// Output:
+	// load: get user: user not found\nread config: unexpected EOF [INTERNAL] at main.load (main.go:42)
+	// └── get user: user not found\nread config: unexpected EOF at github.com/bzon/errors_test.ExampleTree (tree_test.go:17)
+	//     ├── get user: user not found at github.com/bzon/errors_test.ExampleTree (tree_test.go:18)
+	//     │   └── user not found [NOT_FOUND] at main.findUser (user.go:12)
+	//     └── read config: unexpected EOF
+	//         └── unexpected EOF
+}
+
+func ExampleTreeOf() {
+	err := errors.NewO("user not found", errors.NotFound,
+		errors.WithSourceLocation(errors.SourceLocation{Function: "main.findUser", File: "user.go", Line: 12}))
+	b, _ := json.Marshal(errors.TreeOf(fmt.Errorf("get user: %w", err)))
+	fmt.Println(string(b))
+
+	// Output:
+	// {"message":"get user: user not found","causes":[{"message":"user not found","code":"NOT_FOUND","sourceLocation":{"function":"main.findUser","file":"user.go","line":12}}]}
+}
@@ -0,0 +1,206 @@
+// Package twirperr converts errors to and from Twirp errors.
+// The trace context and ID of the errors are carried as metadata of the Twirp errors,
+// and their source location with SendDebugInfo.
+//
+//	server := example.NewHaberdasherServer(svc, twirp.WithServerInterceptors(twirperr.ServerInterceptor()))
+//	client := example.NewHaberdasherProtobufClient(url, http.DefaultClient, twirp.WithClientInterceptors(twirperr.ClientInterceptor()))
+package twirperr
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/errorspb"
+	"github.com/twitchtv/twirp"
+)
+
+// The metadata keys of the Twirp errors created by this package.
+const (
+	MetaTrace    = "trace"
+	MetaSpanID   = "spanId"
+	MetaFunction = "function"
+	MetaFile     = "file"
+	MetaLine     = "line"
+	MetaErrorID  = "errorId"
+)
+
+// SendDebugInfo adds the source location of the errors to the metadata of the Twirp errors created by ToTwirp.
+// It is meant for trusted clients, e.g. the other services of the same system, and is not sent by default.
+var SendDebugInfo bool
+
+var twirpCodes = map[errors.Code]twirp.ErrorCode{
+	errors.OK:                 twirp.NoError,
+	errors.Canceled:           twirp.Canceled,
+	errors.Unknown:            twirp.Unknown,
+	errors.InvalidArgument:    twirp.InvalidArgument,
+	errors.DeadlineExceeded:   twirp.DeadlineExceeded,
+	errors.NotFound:           twirp.NotFound,
+	errors.AlreadyExists:      twirp.AlreadyExists,
+	errors.PermissionDenied:   twirp.PermissionDenied,
+	errors.ResourceExhausted:  twirp.ResourceExhausted,
+	errors.FailedPrecondition: twirp.FailedPrecondition,
+	errors.Aborted:            twirp.Aborted,
+	errors.OutOfRange:         twirp.OutOfRange,
+	errors.Unimplemented:      twirp.Unimplemented,
+	errors.Internal:           twirp.Internal,
+	errors.Unavailable:        twirp.Unavailable,
+	errors.DataLoss:           twirp.DataLoss,
+	errors.Unauthenticated:    twirp.Unauthenticated,
+}
+
+// codes are the codes of the Twirp error codes.
+// The Twirp specific malformed and bad_route codes are InvalidArgument and Unimplemented.
+var codes = func() map[twirp.ErrorCode]errors.Code {
+	m := map[twirp.ErrorCode]errors.Code{
+		twirp.Malformed: errors.InvalidArgument,
+		twirp.BadRoute:  errors.Unimplemented,
+	}
+	for c, tc := range twirpCodes {
+		m[tc] = c
+	}
+	return m
+}()
+
+// ServerInterceptor returns a Twirp server interceptor that wraps the errors of handlers
+// with the service and method names, annotates them on the span of the context and converts them to Twirp errors, see ToTwirp.
+// The errors keep the source location where they occurred, see errors.AtOrigin.
+func ServerInterceptor() twirp.Interceptor {
+	return func(next twirp.Method) twirp.Method {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			resp, err := next(ctx, req)
+			if err != nil {
+				return resp, ToTwirp(errors.WrapO(err, methodName(ctx), errors.WithContext(ctx), errors.AtOrigin()))
+			}
+			return resp, nil
+		}
+	}
+}
+
+// methodName returns the name of the method of ctx, e.g. Haberdasher/MakeHat, or twirp if it has none.
+func methodName(ctx context.Context) string {
+	service, _ := twirp.ServiceName(ctx)
+	method, ok := twirp.MethodName(ctx)
+	if !ok {
+		return "twirp"
+	}
+	return service + "/" + method
+}
+
+// ClientInterceptor returns a Twirp client interceptor that converts the Twirp errors of calls
+// back into ErrorTracer errors, see FromTwirp.
+func ClientInterceptor() twirp.Interceptor {
+	return func(next twirp.Method) twirp.Method {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			resp, err := next(ctx, req)
+			return resp, FromError(err)
+		}
+	}
+}
+
+// ToTwirp converts err to a Twirp error with its code and public message, see errors.PublicMessage,
+// so that the internal message of err is never sent to clients.
+// When err has no public message and a Twirp error is in its chain, e.g. twirp.NotFoundError returned by a handler,
+// the message and metadata of that Twirp error are kept instead, and its code when err has no code.
+// The trace context and ID of the outermost ErrorTracer in the chain of err are added as metadata,
+// and its source location with SendDebugInfo.
+// Twirp errors are returned as is. It returns nil for a nil error.
+func ToTwirp(err error) twirp.Error {
+	if err == nil {
+		return nil
+	}
+	if terr, ok := err.(twirp.Error); ok {
+		return terr
+	}
+	errors.AnnotateDeferred(err)
+	terr := baseError(err)
+	e, ok := errors.TracerOf(err)
+	if !ok {
+		return terr
+	}
+	terr = terr.WithMeta(MetaErrorID, errors.IDOf(e))
+	if SendDebugInfo {
+		src := e.SourceLocation()
+		terr = terr.
+			WithMeta(MetaFunction, src.Function).
+			WithMeta(MetaFile, src.File).
+			WithMeta(MetaLine, strconv.Itoa(src.Line))
+	}
+	if tc := e.TraceContext(); tc.TraceID != "" {
+		terr = terr.WithMeta(MetaTrace, tc.TraceID).WithMeta(MetaSpanID, tc.SpanID)
+	}
+	return terr
+}
+
+// baseError returns the Twirp error that ToTwirp adds the metadata of err to.
+// It is a copy of the first Twirp error in the chain of err when err has no public message,
+// without the metadata of this package, since it describes the error of another service.
+// Otherwise, it is a new Twirp error with the code and public message of err.
+func baseError(err error) twirp.Error {
+	code, ok := twirpCodes[errors.CodeOf(err)]
+	if !ok {
+		code = twirp.Unknown
+	}
+	native, ok := errors.AsType[twirp.Error](err)
+	if !ok || errors.HasPublicMessage(err) {
+		return twirp.NewError(code, errors.PublicMessage(err))
+	}
+	if errors.CodeOf(err) == errors.Unknown {
+		code = native.Code()
+	}
+	terr := twirp.NewError(code, native.Msg())
+	for k, v := range native.MetaMap() {
+		switch k {
+		case MetaTrace, MetaSpanID, MetaFunction, MetaFile, MetaLine, MetaErrorID:
+			continue
+		}
+		terr = terr.WithMeta(k, v)
+	}
+	return terr
+}
+
+// FromError converts a Twirp error in the chain of err to an ErrorTracer error, see FromTwirp.
+// The Twirp error is kept in the chain of the error, so that errors.As still finds it.
+// Other errors are returned as is.
+func FromError(err error) error {
+	terr, ok := errors.AsType[twirp.Error](err)
+	if !ok {
+		return err
+	}
+	return FromTwirp(terr)
+}
+
+// FromTwirp converts a Twirp error to an ErrorTracer error with its code and message.
+// The source location, trace context and ID are restored from the metadata of Twirp errors created by ToTwirp.
+// The Twirp error is the cause of the error, so that errors.As still finds it.
+// It returns nil for a nil error.
+func FromTwirp(terr twirp.Error) error {
+	if terr == nil {
+		return nil
+	}
+	code, ok := codes[terr.Code()]
+	if !ok {
+		code = errors.Unknown
+	}
+	p := &errorspb.Error{
+		Message: terr.Msg(),
+		Traced:  true,
+		Code:    int32(code),
+		Id:      terr.Meta(MetaErrorID),
+	}
+	if fn := terr.Meta(MetaFunction); fn != "" {
+		line, _ := strconv.ParseInt(terr.Meta(MetaLine), 10, 64)
+		p.SourceLocation = &errorspb.SourceLocation{
+			Function: fn,
+			File:     terr.Meta(MetaFile),
+			Line:     line,
+		}
+	}
+	if traceID := terr.Meta(MetaTrace); traceID != "" {
+		p.TraceContext = &errorspb.TraceContext{
+			TraceId: traceID,
+			SpanId:  terr.Meta(MetaSpanID),
+		}
+	}
+	return errors.FromProtoWithCause(p, terr)
+}
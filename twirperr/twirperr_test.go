@@ -0,0 +1,90 @@
+package twirperr_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/twirperr"
+	"github.com/twitchtv/twirp"
+)
+
+func ExampleToTwirp() {
+	// The server sends its source locations to trusted clients.
+	twirperr.SendDebugInfo = true
+	defer func() { twirperr.SendDebugInfo = false }()
+
+	err := errors.NewO("select user 1: no rows", errors.NotFound, errors.WithTraceContext(errors.TraceContext{
+		TraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:  "00f067aa0ba902b7",
+	}))
+	err = errors.WithPublicMessage(err, "user not found")
+
+	terr := twirperr.ToTwirp(err)
+	fmt.Println(terr.Code(), terr.Msg())
+	fmt.Println(terr.Meta(twirperr.MetaTrace), terr.Meta(twirperr.MetaFunction))
+
+	back := twirperr.FromTwirp(terr)
+	e := back.(errors.ErrorTracer)
+	fmt.Println(back, errors.CodeOf(back))
+	fmt.Println(e.TraceContext().SpanID, e.SourceLocation().Function)
+	fmt.Println(errors.IDOf(e) == errors.IDOf(err))
+	_, ok := errors.AsType[twirp.Error](back)
+	fmt.Println(ok)
+
+	// Output:
+	// not_found user not found
+	// 4bf92f3577b34da6a3ce929d0e0e4736 github.com/bzon/errors/twirperr_test.ExampleToTwirp
+	// user not found NOT_FOUND
+	// 00f067aa0ba902b7 github.com/bzon/errors/twirperr_test.ExampleToTwirp
+	// true
+	// true
+}
+
+func ExampleServerInterceptor() {
+	method := twirp.ChainInterceptors(twirperr.ClientInterceptor(), twirperr.ServerInterceptor())(
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errors.WithCode(errors.New("invalid hat size"), errors.InvalidArgument)
+		},
+	)
+
+	_, err := method(context.Background(), nil)
+	fmt.Println(err)
+	fmt.Println(errors.CodeOf(err))
+
+	// Output:
+	// Bad Request
+	// INVALID_ARGUMENT
+}
+
+func ExampleServerInterceptor_twirpError() {
+	method := twirperr.ServerInterceptor()(func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, twirp.NotFoundError("user 42 not found").WithMeta("user", "42")
+	})
+
+	_, err := method(context.Background(), nil)
+	terr := err.(twirp.Error)
+	fmt.Println(terr.Code(), terr.Msg(), terr.Meta("user"))
+	fmt.Println(terr.Meta(twirperr.MetaErrorID) != "")
+
+	// Output:
+	// not_found user 42 not found 42
+	// true
+}
+
+func ExampleToTwirp_wrapped() {
+	terr := twirperr.ToTwirp(fmt.Errorf("wrapped: %w", twirp.NotFoundError("user 42 not found")))
+	fmt.Println(terr.Code(), terr.Msg())
+
+	// An explicit code and public message take precedence.
+	err := errors.WithCode(fmt.Errorf("wrapped: %w", twirp.NotFoundError("user 42 not found")), errors.Internal)
+	terr = twirperr.ToTwirp(err)
+	fmt.Println(terr.Code(), terr.Msg())
+	terr = twirperr.ToTwirp(errors.WithPublicMessage(err, "try again later"))
+	fmt.Println(terr.Code(), terr.Msg())
+
+	// Output:
+	// not_found user 42 not found
+	// internal user 42 not found
+	// internal try again later
+}
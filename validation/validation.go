@@ -0,0 +1,77 @@
+// Package validation accumulates the field violations of a request into a single InvalidArgument error,
+// with a google.rpc.BadRequest detail, that is written as an HTTP 400 problem with its invalid-params
+// by errors.WriteProblem, and as a gRPC status with the BadRequest detail by grpcerr.ToStatus.
+//
+//	var v validation.Violations
+//	if !strings.Contains(req.Email, "@") {
+//		v.Add("email", "must be valid")
+//	}
+//	v.Check(req.Name != "", "name", "is required")
+//	if err := v.Err(); err != nil {
+//		return err
+//	}
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bzon/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// Violations are the field violations of a request.
+// The zero value has no violations and is ready to use.
+type Violations struct {
+	violations []*errdetails.BadRequest_FieldViolation
+}
+
+// Add adds the violation of field, e.g. a path like address.city, described by description.
+func (v *Violations) Add(field, description string) {
+	v.violations = append(v.violations, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: description,
+	})
+}
+
+// Addf adds the violation of field described by a format, see Add.
+func (v *Violations) Addf(field, format string, args ...interface{}) {
+	v.Add(field, fmt.Sprintf(format, args...))
+}
+
+// Check adds the violation of field described by description when ok is false.
+// It reports ok.
+func (v *Violations) Check(ok bool, field, description string) bool {
+	if !ok {
+		v.Add(field, description)
+	}
+	return ok
+}
+
+// Len returns the number of violations.
+func (v *Violations) Len() int {
+	return len(v.violations)
+}
+
+// Err returns an InvalidArgument error whose message and public message list the violations,
+// with a google.rpc.BadRequest detail, see errors.WithDetail.
+// Its source location is the caller of Err, and opts are applied to it, e.g. errors.WithSpan.
+// It returns nil when there are no violations.
+func (v *Violations) Err(opts ...errors.Option) error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(v.violations))
+	for i, fv := range v.violations {
+		msgs[i] = fv.Field + ": " + fv.Description
+	}
+	msg := strings.Join(msgs, "; ")
+
+	// Skip Err.
+	opts = append([]errors.Option{errors.InvalidArgument, errors.WithDepth(3)}, opts...)
+	err := errors.NewO("invalid argument: "+msg, opts...)
+	err = errors.WithPublicMessage(err, msg)
+	return errors.WithDetail(err, &errdetails.BadRequest{
+		FieldViolations: append([]*errdetails.BadRequest_FieldViolation(nil), v.violations...),
+	})
+}
@@ -0,0 +1,29 @@
+package validation_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/bzon/errors"
+	"github.com/bzon/errors/validation"
+)
+
+func ExampleViolations() {
+	var v validation.Violations
+	v.Add("email", "must be valid")
+	v.Check(len("") > 0, "name", "is required")
+
+	err := v.Err()
+	fmt.Println(err)
+	fmt.Println(errors.CodeOf(err), errors.HTTPStatus(err))
+	fmt.Println(err.(errors.ErrorTracer).SourceLocation().Function)
+
+	b, _ := json.Marshal(errors.ToProblem(err).InvalidParams)
+	fmt.Println(string(b))
+
+	// Output:
+	// invalid argument: email: must be valid; name: is required
+	// INVALID_ARGUMENT 400
+	// github.com/bzon/errors/validation_test.ExampleViolations
+	// [{"name":"email","reason":"must be valid"},{"name":"name","reason":"is required"}]
+}
@@ -0,0 +1,330 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bzon/errors/errorspb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// remoteContext is the context of an error that is not known to this process.
+type remoteContext struct {
+	id    string
+	stack []SourceLocation
+	// fingerprint is the fingerprint of the error in the other process, see FromHeaders.
+	fingerprint string
+}
+
+// remoteError is an error of another process, see Unmarshal.
+type remoteError struct {
+	msg   string
+	cause error
+}
+
+func (e *remoteError) Error() string {
+	return e.msg
+}
+
+func (e *remoteError) Unwrap() error {
+	return e.cause
+}
+
+// remoteJoinError is a multi-error of another process, see Unmarshal.
+type remoteJoinError struct {
+	msg    string
+	causes []error
+}
+
+func (e *remoteJoinError) Error() string {
+	return e.msg
+}
+
+func (e *remoteJoinError) Unwrap() []error {
+	return e.causes
+}
+
+// Marshal encodes err and its chain of causes as JSON, see errorspb.Error.
+// The messages, codes, source locations, trace contexts and the other attributes of the ErrorTracer
+// errors in the chain are kept, so that the error can cross process boundaries, e.g. through a queue,
+// and be decoded by Unmarshal. The fields are encoded as JSON values.
+// The messages and the values of the fields are scrubbed by the package-global redactors, see AddRedactor,
+// unless MarshalOptions.Unredacted is set.
+// It returns nil for a nil error.
+func Marshal(err error) []byte {
+	return MarshalOptions{}.Marshal(err)
+}
+
+// MarshalOptions are the options of Marshal and MarshalProto.
+type MarshalOptions struct {
+	// Unredacted keeps the messages and the values of the fields as they are, instead of scrubbing them
+	// with the package-global redactors, e.g. for the errors sent to a queue that only trusted consumers read.
+	Unredacted bool
+}
+
+// Marshal is Marshal with the options o.
+func (o MarshalOptions) Marshal(err error) []byte {
+	if err == nil {
+		return nil
+	}
+	b, _ := protojson.Marshal(o.toProto(err))
+	return b
+}
+
+// MarshalProto is MarshalProto with the options o.
+func (o MarshalOptions) MarshalProto(err error) []byte {
+	if err == nil {
+		return nil
+	}
+	b, _ := proto.Marshal(o.toProto(err))
+	return b
+}
+
+// toProto is ToProto, with the messages and fields redacted unless o.Unredacted is set.
+func (o MarshalOptions) toProto(err error) *errorspb.Error {
+	p := ToProto(err)
+	if rs, _ := redactors.Load().([]Redactor); len(rs) > 0 && !o.Unredacted {
+		redactProto(p)
+	}
+	return p
+}
+
+// redactProto scrubs the messages and the values of the fields of p and its causes with the package-global redactors,
+// like RedactedMessage and RedactedFields.
+func redactProto(p *errorspb.Error) {
+	if p == nil {
+		return
+	}
+	p.Message = redact("", p.Message)
+	for k, v := range p.Fields {
+		if s, ok := v.GetKind().(*structpb.Value_StringValue); ok {
+			p.Fields[k] = structpb.NewStringValue(redact(k, s.StringValue))
+			continue
+		}
+		s := fmt.Sprint(v.AsInterface())
+		if r := redact(k, s); r != s {
+			p.Fields[k] = structpb.NewStringValue(r)
+		}
+	}
+	for _, c := range p.Causes {
+		redactProto(c)
+	}
+}
+
+// Unmarshal decodes an error encoded by Marshal.
+// The ErrorTracer errors of the chain are restored as ErrorTracer errors, the others keep only their messages.
+// It returns nil for an empty input, and a DataLoss error when data cannot be decoded.
+func Unmarshal(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	p := &errorspb.Error{}
+	if err := protojson.Unmarshal(data, p); err != nil {
+		return WithCode(WrapCaller(wrappedFunctionCallDepth+1, err, "unmarshal error"), DataLoss)
+	}
+	return FromProto(p)
+}
+
+// MarshalProto encodes err like Marshal, as protocol buffers.
+func MarshalProto(err error) []byte {
+	return MarshalOptions{}.MarshalProto(err)
+}
+
+// UnmarshalProto decodes an error encoded by MarshalProto, like Unmarshal.
+func UnmarshalProto(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	p := &errorspb.Error{}
+	if err := proto.Unmarshal(data, p); err != nil {
+		return WithCode(WrapCaller(wrappedFunctionCallDepth+1, err, "unmarshal error"), DataLoss)
+	}
+	return FromProto(p)
+}
+
+// ToProto converts err and its chain of causes to protocol buffers, see Marshal.
+// The messages and fields are not redacted.
+// The causes deeper than Options.MaxChainDepth wraps, or that loop back to an error of their chain,
+// are replaced by a [chain truncated] cause.
+// It returns nil for a nil error.
+func ToProto(err error) *errorspb.Error {
+	AnnotateDeferred(err)
+	return toProto(err, maxChainDepth(), &chainCycle{})
+}
+
+// toProto is ToProto down to depth wraps, an error of this package and the error it carries being one. The cycle detects the cycles of the chain of the branch of err.
+func toProto(err error, depth int, cycle *chainCycle) *errorspb.Error {
+	if err == nil {
+		return nil
+	}
+	if depth <= 0 || cycle.seen(err) {
+		return &errorspb.Error{Message: chainTruncated}
+	}
+	p := &errorspb.Error{Message: err.Error()}
+	next := err
+	if e, ok := err.(*errorContext); ok {
+		p.Traced = true
+		p.Code = int32(e.code)
+		p.SourceLocation = sourceLocationToProto(e.SourceLocation())
+		tc := e.TraceContext()
+		p.TraceContext = &errorspb.TraceContext{
+			TraceId:    tc.TraceID,
+			SpanId:     tc.SpanID,
+			TraceFlags: uint32(tc.TraceFlags),
+		}
+		p.HttpStatus = int32(e.httpStatus)
+		p.Op = e.op
+		p.Severity = int32(e.severity)
+		p.PublicMessage = e.publicMessage
+		if !e.occurredAt.IsZero() {
+			p.OccurredAt = timestamppb.New(e.occurredAt)
+		}
+		p.Id = e.ownID()
+		for _, src := range e.Stack() {
+			p.Stack = append(p.Stack, sourceLocationToProto(src))
+		}
+		if len(e.fields) > 0 {
+			p.Fields = make(map[string]*structpb.Value, len(e.fields))
+			for k, v := range e.fields {
+				value, err := structpb.NewValue(v)
+				if err != nil {
+					value = structpb.NewStringValue(fmt.Sprint(v))
+				}
+				p.Fields[k] = value
+			}
+		}
+		for _, v := range e.details {
+			p.Details = append(p.Details, detailToProto(v))
+		}
+		if r := e.runtime; r != nil {
+			p.Runtime = &errorspb.Runtime{
+				GoroutineId: r.GoroutineID,
+				Goos:        r.GOOS,
+				Goarch:      r.GOARCH,
+				GoVersion:   r.GoVersion,
+				Hostname:    r.Hostname,
+				Pod:         r.Pod,
+			}
+		}
+		// The message of e is the message of e.err.
+		next = e.err
+	}
+	if m, ok := next.(interface{ Unwrap() []error }); ok {
+		for _, cause := range m.Unwrap() {
+			if cause != nil {
+				p.Causes = append(p.Causes, toProto(cause, depth-1, &chainCycle{}))
+			}
+		}
+	} else if cause := errors.Unwrap(next); cause != nil {
+		p.Causes = []*errorspb.Error{toProto(cause, depth-1, cycle)}
+	}
+	return p
+}
+
+// FromProto converts protocol buffers created by ToProto back to an error, see Unmarshal.
+// It returns nil for nil protocol buffers.
+func FromProto(p *errorspb.Error) error {
+	return fromProto(p, nil)
+}
+
+// FromProtoWithCause is FromProto, with cause as the cause of the innermost error of p.
+// The cause is the error that p was decoded from, e.g. a gRPC status error,
+// so that errors.Is and errors.As still find it in the chain of the error.
+// Its message is not part of the messages of the error.
+func FromProtoWithCause(p *errorspb.Error, cause error) error {
+	return fromProto(p, cause)
+}
+
+// fromProto is FromProto, with cause as the cause of the innermost error of p, following its first causes.
+func fromProto(p *errorspb.Error, cause error) error {
+	if p == nil {
+		return nil
+	}
+	var err error
+	switch len(p.Causes) {
+	case 0:
+		err = &remoteError{msg: p.Message, cause: cause}
+	case 1:
+		err = &remoteError{msg: p.Message, cause: fromProto(p.Causes[0], cause)}
+	default:
+		causes := make([]error, len(p.Causes))
+		for i, c := range p.Causes {
+			if i > 0 {
+				cause = nil
+			}
+			causes[i] = fromProto(c, cause)
+		}
+		err = &remoteJoinError{msg: p.Message, causes: causes}
+	}
+	if !p.Traced {
+		return err
+	}
+
+	src := sourceLocationFromProto(p.SourceLocation)
+	e := newContext(errorContext{
+		err:            err,
+		sourceLocation: location{src: &src},
+		traceContext: TraceContext{
+			TraceID:    p.TraceContext.GetTraceId(),
+			SpanID:     p.TraceContext.GetSpanId(),
+			TraceFlags: byte(p.TraceContext.GetTraceFlags()),
+		},
+		code:          Code(p.Code),
+		httpStatus:    int(p.HttpStatus),
+		op:            p.Op,
+		severity:      Severity(p.Severity),
+		publicMessage: p.PublicMessage,
+		remote:        &remoteContext{id: p.Id},
+	})
+	if p.OccurredAt != nil {
+		e.occurredAt = p.OccurredAt.AsTime()
+	}
+	for _, src := range p.Stack {
+		e.remote.stack = append(e.remote.stack, sourceLocationFromProto(src))
+	}
+	if len(p.Fields) > 0 {
+		e.fields = make(Fields, len(p.Fields))
+		for k, v := range p.Fields {
+			e.fields[k] = v.AsInterface()
+		}
+	}
+	for _, v := range p.Details {
+		e.details = append(e.details, detailFromProto(v))
+	}
+	if r := p.Runtime; r != nil {
+		e.runtime = &Runtime{
+			GoroutineID: r.GoroutineId,
+			GOOS:        r.Goos,
+			GOARCH:      r.Goarch,
+			GoVersion:   r.GoVersion,
+			Hostname:    r.Hostname,
+			Pod:         r.Pod,
+		}
+	}
+	return e
+}
+
+func sourceLocationToProto(src SourceLocation) *errorspb.SourceLocation {
+	return &errorspb.SourceLocation{
+		Function: src.Function,
+		File:     src.File,
+		Line:     int64(src.Line),
+		Version:  src.Version,
+		Commit:   src.Commit,
+		Branch:   src.Branch,
+	}
+}
+
+func sourceLocationFromProto(p *errorspb.SourceLocation) SourceLocation {
+	return SourceLocation{
+		Function: p.GetFunction(),
+		File:     p.GetFile(),
+		Line:     int(p.GetLine()),
+		Version:  p.GetVersion(),
+		Commit:   p.GetCommit(),
+		Branch:   p.GetBranch(),
+	}
+}
@@ -0,0 +1,97 @@
+package errors_test
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleMarshal() {
+	err := errors.NewO("user not found", errors.NotFound, errors.WithFields(errors.Fields{"user": 1}))
+	err = errors.WithOp(errors.Wrap(err, "get user"), "userservice.GetUser")
+
+	// On the producer side.
+	data := errors.Marshal(err)
+
+	// On the consumer side.
+	remote := errors.Unmarshal(data)
+	fmt.Println(remote)
+	fmt.Println(errors.CodeOf(remote), errors.FieldsOf(remote), errors.Ops(remote))
+	e := remote.(errors.ErrorTracer)
+	fmt.Println(e.SourceLocation() == err.(errors.ErrorTracer).SourceLocation())
+	fmt.Println(errors.IDOf(e) == errors.IDOf(err))
+	fmt.Println(len(errors.FramesOf(e)))
+	fmt.Println(errors.Unmarshal([]byte("{")) != nil)
+
+	// Output:
+	// get user: user not found
+	// NOT_FOUND map[user:1] [userservice.GetUser]
+	// true
+	// true
+	// 2
+	// true
+}
+
+func ExampleMarshalOptions() {
+	errors.AddRedactor(errors.RedactPattern(regexp.MustCompile(`[\w.+-]+@[\w-]+\.[\w.]+`), "[EMAIL]"))
+	errors.AddRedactor(errors.RedactKeys("password"))
+	defer errors.ResetRedactors()
+
+	err := errors.NewO("invalid login of jane@example.com",
+		errors.WithFields(errors.Fields{"password": "hunter2", "attempts": 3}),
+	)
+	err = errors.Wrap(err, "login")
+
+	// The errors are redacted by default.
+	remote := errors.Unmarshal(errors.Marshal(err))
+	fmt.Println(remote, errors.FieldsOf(remote))
+
+	// Unless they are only read by trusted consumers.
+	remote = errors.Unmarshal(errors.MarshalOptions{Unredacted: true}.Marshal(err))
+	fmt.Println(remote, errors.FieldsOf(remote))
+
+	// Output:
+	// login: invalid login of [EMAIL] map[attempts:3 password:[REDACTED]]
+	// login: invalid login of jane@example.com map[attempts:3 password:hunter2]
+}
+
+func ExampleMarshalProto() {
+	err := errors.Join(errors.New("a"), errors.WithCode(errors.New("b"), errors.Unavailable))
+
+	remote := errors.UnmarshalProto(errors.MarshalProto(err))
+	fmt.Printf("%q\n", remote)
+	fmt.Println(errors.CodeOf(remote))
+	fmt.Println(errors.Marshal(nil) == nil, errors.Unmarshal(nil) == nil)
+
+	// Output:
+	// "a\nb"
+	// UNAVAILABLE
+	// true true
+}
+
+func ExampleToProto() {
+	err := errors.Wrap(errors.WithCode(errors.New("user not found"), errors.NotFound), "get user")
+
+	p := errors.ToProto(err)
+	fmt.Println(p.Message, p.Causes[0].Message, p.Causes[0].Code)
+	fmt.Println(errors.FromProto(p))
+
+	// Output:
+	// get user: user not found user not found 5
+	// get user: user not found
+}
+
+func ExampleFromProtoWithCause() {
+	// The error of a transport, that carried the protocol buffers.
+	errTransport := fmt.Errorf("transport: user not found")
+	p := errors.ToProto(errors.WithCode(errors.New("user not found"), errors.NotFound))
+
+	err := errors.FromProtoWithCause(p, errTransport)
+	fmt.Println(err, errors.CodeOf(err))
+	fmt.Println(errors.Is(err, errTransport))
+
+	// Output:
+	// user not found NOT_FOUND
+	// true
+}
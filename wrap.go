@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WrapDefer wraps the error *errp with m, if it is not nil.
+// It is meant to be deferred, to wrap all the errors returned by a function with named results:
+//
+//	func (s *Service) CreateUser(u User) (err error) {
+//		defer errors.WrapDefer(&err, "create user")
+//
+// The source location is the one of the function that deferred WrapDefer.
+func WrapDefer(errp *error, m string) {
+	if *errp == nil {
+		return
+	}
+	err := newErrorContext(wrapMessage(*errp, m), captureLocation(wrappedFunctionCallDepth))
+	*errp = created(err, nil)
+}
+
+// WrapDeferf is WrapDefer with formatting.
+func WrapDeferf(errp *error, f string, args ...interface{}) {
+	if *errp == nil {
+		return
+	}
+	m := fmt.Sprintf(f, args...)
+	err := newErrorContext(wrapMessage(*errp, m), captureLocation(wrappedFunctionCallDepth))
+	*errp = created(err, nil)
+}
+
+// WrapIf wraps e with m, like Wrap, if cond is true, and returns e as is otherwise.
+// It returns nil if e is nil.
+//
+//	return errors.WrapIf(!errors.Is(err, io.EOF), err, "read header")
+func WrapIf(cond bool, e error, m string) error {
+	if e == nil || !cond {
+		return e
+	}
+	err := newErrorContext(wrapMessage(e, m), captureLocation(wrappedFunctionCallDepth))
+	return created(err, nil)
+}
+
+// wrapError is the error of the wrapping constructors.
+// It is the error of fmt.Errorf("%s: %w", msg, err), without the scanning of the format:
+// its message is built once, with a single concatenation.
+type wrapError struct {
+	msg string
+	err error
+}
+
+// wrapMessage wraps err with the message m.
+// When the chain of err is deeper than Options.MaxChainDepth, the messages of its chain down to
+// that depth are replaced by a marker, once: the errors that wrap a truncated message keep its tail.
+func wrapMessage(err error, m string) error {
+	msg := err.Error()
+	if chainDepthAtLeast(err, maxChainDepth()) {
+		if i := strings.Index(msg, chainTruncated); i >= 0 {
+			msg = msg[i:]
+		} else {
+			msg = chainTruncated + ": " + Cause(err).Error()
+		}
+	}
+	return &wrapError{msg: m + ": " + msg, err: err}
+}
+
+func (e *wrapError) Error() string {
+	return e.msg
+}
+
+func (e *wrapError) Unwrap() error {
+	return e.err
+}
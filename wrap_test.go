@@ -0,0 +1,39 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bzon/errors"
+)
+
+func createUser(name string) (err error) {
+	defer errors.WrapDeferf(&err, "create user %q", name)
+
+	if name == "" {
+		return errors.New("empty name")
+	}
+	return nil
+}
+
+func ExampleWrapDefer() {
+	fmt.Println(createUser("gopher"))
+
+	err := createUser("")
+	fmt.Println(err)
+	fmt.Println(err.(errors.ErrorTracer).SourceLocation().Function)
+
+	// Output:
+	// <nil>
+	// create user "": empty name
+	// github.com/bzon/errors_test.createUser
+}
+
+func ExampleWrapIf() {
+	fmt.Println(errors.WrapIf(false, io.EOF, "read header"))
+	fmt.Println(errors.WrapIf(true, io.ErrUnexpectedEOF, "read header"))
+
+	// Output:
+	// EOF
+	// read header: unexpected EOF
+}
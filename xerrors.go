@@ -0,0 +1,57 @@
+package errors
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Compile time implementation check.
+var _ xerrors.Formatter = &errorContext{}
+
+// FormatError implements xerrors.Formatter, so that the printers of the golang.org/x/xerrors formatting protocol
+// print every layer of the chain with its own message and, in detail mode, e.g. with %+v, its source location.
+// The layers of this package that carry no message of their own, e.g. the copies of WithCode,
+// are printed with the message of the error they carry.
+func (e *errorContext) FormatError(p xerrors.Printer) error {
+	layers := []*errorContext{e}
+	err := e.err
+	for {
+		c, ok := err.(*errorContext)
+		if !ok {
+			break
+		}
+		layers = append(layers, c)
+		err = c.err
+	}
+	msg, next := splitMessage(err)
+	p.Print(msg)
+	if p.Detail() {
+		for _, l := range layers {
+			if src := l.SourceLocation(); src.File != "" {
+				p.Printf("%s\n    %s:%d\n", src.Function, src.File, src.Line)
+			}
+		}
+	}
+	return next
+}
+
+// splitMessage returns the own message of err, without the message of the error it wraps, and the wrapped error.
+// The multi-errors, and the errors whose message does not end with the message of the error they wrap,
+// are returned with their whole message and no wrapped error.
+func splitMessage(err error) (string, error) {
+	if _, ok := err.(interface{ Unwrap() []error }); ok {
+		return err.Error(), nil
+	}
+	msg := err.Error()
+	next := errors.Unwrap(err)
+	if next == nil {
+		return msg, nil
+	}
+	own, ok := strings.CutSuffix(msg, ": "+next.Error())
+	if !ok {
+		return msg, nil
+	}
+	return own, next
+}
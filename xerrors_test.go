@@ -0,0 +1,41 @@
+package errors_test
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bzon/errors"
+	"golang.org/x/xerrors"
+)
+
+// xerrorsPrinter prints its error with the golang.org/x/xerrors formatting protocol.
+type xerrorsPrinter struct {
+	err xerrors.Formatter
+}
+
+func (p xerrorsPrinter) Format(s fmt.State, verb rune) {
+	xerrors.FormatError(p.err, s, verb)
+}
+
+func ExampleErrorTracer_formatError() {
+	loc := func(function string, line int) errors.Option {
+		return errors.WithSourceLocation(errors.SourceLocation{Function: function, File: "user.go", Line: line})
+	}
+	err := errors.WrapO(os.ErrNotExist, "open user", loc("main.openUser", 12))
+	err = errors.WithCode(err, errors.NotFound)
+	err = errors.WrapO(err, "get user", loc("main.getUser", 30))
+
+	p := xerrorsPrinter{err.(xerrors.Formatter)}
+	fmt.Printf("%v\n", p)
+	fmt.Printf("%+v\n", p)
+
+	// Output:
+	// get user: open user: file does not exist
+	// get user:
+	//     main.getUser
+	//         user.go:30
+	//   - open user:
+	//     main.openUser
+	//         user.go:12
+	//   - file does not exist
+}
@@ -0,0 +1,77 @@
+package errors
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const xrayTraceIDVersion = "1"
+
+// XRayTraceID returns the trace ID in the AWS X-Ray format, 1-<epoch>-<id>,
+// e.g. 1-5759e988-bd862e3fe1be46a994272793.
+// The first 8 hexadecimal digits of the trace ID are the epoch, the remaining 24 the ID.
+// It returns an empty string when the trace ID is not 32 hexadecimal digits.
+// See https://docs.aws.amazon.com/xray/latest/devguide/xray-api-sendingdata.html#xray-api-traceids.
+func (t TraceContext) XRayTraceID() string {
+	if len(t.TraceID) != 32 {
+		return ""
+	}
+	return xrayTraceIDVersion + "-" + t.TraceID[:8] + "-" + t.TraceID[8:]
+}
+
+// XRayHeader returns the trace context in the X-Amzn-Trace-Id header format,
+// e.g. Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1.
+// It returns an empty string when there is no trace context.
+// See https://docs.aws.amazon.com/xray/latest/devguide/xray-concepts.html#xray-concepts-tracingheader.
+func (t TraceContext) XRayHeader() string {
+	root := t.XRayTraceID()
+	if root == "" {
+		return ""
+	}
+	h := "Root=" + root
+	if t.SpanID != "" {
+		h += ";Parent=" + t.SpanID
+	}
+	return fmt.Sprintf("%s;Sampled=%d", h, t.TraceFlags&1)
+}
+
+// ParseXRayHeader parses an X-Amzn-Trace-Id header into a trace context.
+// The Parent is the span ID and Sampled the sampled trace flag, both are optional.
+// Use WithTraceContext to set it on an error.
+func ParseXRayHeader(header string) (TraceContext, error) {
+	var tc TraceContext
+	for _, kv := range strings.Split(header, ";") {
+		k, v, _ := strings.Cut(strings.TrimSpace(kv), "=")
+		switch k {
+		case "Root":
+			parts := strings.Split(v, "-")
+			if len(parts) != 3 || parts[0] != xrayTraceIDVersion || len(parts[1]) != 8 || len(parts[2]) != 24 || !isHex(parts[1]+parts[2]) {
+				return TraceContext{}, fmt.Errorf("invalid X-Amzn-Trace-Id root %q", v)
+			}
+			tc.TraceID = parts[1] + parts[2]
+		case "Parent":
+			if len(v) != 16 || !isHex(v) {
+				return TraceContext{}, fmt.Errorf("invalid X-Amzn-Trace-Id parent %q", v)
+			}
+			tc.SpanID = v
+		case "Sampled":
+			if v == "1" {
+				tc.TraceFlags = 1
+			}
+		}
+	}
+	if tc.TraceID == "" {
+		return TraceContext{}, fmt.Errorf("invalid X-Amzn-Trace-Id %q", header)
+	}
+	return tc, nil
+}
+
+// isHex reports whether s is lowercase hexadecimal.
+func isHex(s string) bool {
+	if strings.ToLower(s) != s {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
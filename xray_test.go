@@ -0,0 +1,37 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/bzon/errors"
+)
+
+func ExampleTraceContext_XRayHeader() {
+	tc := errors.TraceContext{
+		TraceID:    "5759e988bd862e3fe1be46a994272793",
+		SpanID:     "53995c3f42cd8ad8",
+		TraceFlags: 1,
+	}
+	fmt.Println(tc.XRayTraceID())
+	fmt.Println(tc.XRayHeader())
+
+	// Output:
+	// 1-5759e988-bd862e3fe1be46a994272793
+	// Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1
+}
+
+func ExampleParseXRayHeader() {
+	tc, err := errors.ParseXRayHeader("Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1")
+	if err != nil {
+		fmt.Println(err)
+	}
+	err = errors.NewO("a", errors.WithTraceContext(tc))
+	fmt.Println(err.(errors.ErrorTracer).TraceContext())
+
+	_, err = errors.ParseXRayHeader("Root=1-5759e988")
+	fmt.Println(err)
+
+	// Output:
+	// {5759e988bd862e3fe1be46a994272793 53995c3f42cd8ad8 1}
+	// invalid X-Amzn-Trace-Id root "1-5759e988"
+}